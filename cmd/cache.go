@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/command"
+)
+
+func cache() *cli.Command {
+	var verbose bool
+
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "manage cached results of the jobs with `cache: true`",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "verbose",
+				Aliases:     []string{"v"},
+				Destination: &verbose,
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "clear",
+				Usage: "remove all cached job results",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					l, err := command.NewLefthook(verbose, "auto")
+					if err != nil {
+						return err
+					}
+
+					return l.ClearCache(ctx)
+				},
+			},
+		},
+		ShellComplete: func(ctx context.Context, cmd *cli.Command) {
+			command.ShellCompleteFlags(cmd)
+		},
+	}
+}
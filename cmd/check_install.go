@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 
 	"github.com/urfave/cli/v3"
 
@@ -9,7 +10,7 @@ import (
 )
 
 func checkInstall() *cli.Command {
-	var verbose bool
+	var verbose, global bool
 	return &cli.Command{
 		Name:  "check-install",
 		Usage: "check if hooks are installed",
@@ -22,14 +23,30 @@ func checkInstall() *cli.Command {
 				Aliases:     []string{"v"},
 				Destination: &verbose,
 			},
+			&cli.BoolFlag{
+				Name:        "global",
+				Usage:       "check if hooks are installed with install --global",
+				Destination: &global,
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			l, err := command.NewLefthook(verbose, "auto")
-			if err != nil {
-				return err
+			var err error
+			if global {
+				err = command.NewGlobalLefthook(verbose, "auto").CheckGlobalInstall(ctx)
+			} else {
+				var l *command.Lefthook
+				if l, err = command.NewLefthook(verbose, "auto"); err != nil {
+					return err
+				}
+
+				err = l.CheckInstall(ctx)
+			}
+
+			if errors.Is(err, command.ErrNotInstalled) {
+				return errors.New("") // Only the exit code is reported
 			}
 
-			return l.CheckInstall(ctx)
+			return err
 		},
 		ShellComplete: func(ctx context.Context, cmd *cli.Command) {
 			command.ShellCompleteFlags(cmd)
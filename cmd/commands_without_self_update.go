@@ -6,12 +6,16 @@ import "github.com/urfave/cli/v3"
 
 var commands = []*cli.Command{
 	run(),
+	watch(),
 	install(),
 	uninstall(),
 	checkInstall(),
 	dump(),
 	add(),
+	migrate(),
 	validate(),
+	remotes(),
+	cache(),
 	version(),
 	// selfUpdate(),
 }
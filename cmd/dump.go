@@ -37,6 +37,11 @@ func dump() *cli.Command {
 					return nil
 				},
 			},
+			&cli.BoolFlag{
+				Name:        "explain",
+				Usage:       "annotate values with the config files that set them (yaml only)",
+				Destination: &args.Explain,
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			l, err := command.NewLefthook(false, "no")
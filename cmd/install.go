@@ -29,6 +29,21 @@ func install() *cli.Command {
 				Aliases:     []string{"r"},
 				Destination: &args.ResetHooksPath,
 			},
+			&cli.BoolFlag{
+				Name:        "chain",
+				Usage:       "run existing hooks saved with .old suffix before lefthook",
+				Destination: &args.Chain,
+			},
+			&cli.BoolFlag{
+				Name:        "global",
+				Usage:       "install hooks running lefthook in all repositories with a config",
+				Destination: &args.Global,
+			},
+			&cli.BoolFlag{
+				Name:        "template",
+				Usage:       "use init.templateDir instead of core.hooksPath for --global",
+				Destination: &args.Template,
+			},
 			&cli.BoolFlag{
 				Name:        "verbose",
 				Aliases:     []string{"v"},
@@ -36,6 +51,10 @@ func install() *cli.Command {
 			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if args.Global {
+				return command.NewGlobalLefthook(verbose, "auto").InstallGlobal(ctx, args)
+			}
+
 			l, err := command.NewLefthook(verbose, "auto")
 			if err != nil {
 				return err
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/command"
+)
+
+func migrate() *cli.Command {
+	args := command.MigrateArgs{
+		Format: "yaml",
+	}
+	var verbose bool
+
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "generate lefthook config from husky, pre-commit, or overcommit config",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "from",
+				Usage:       "'husky', 'pre-commit', or 'overcommit' (default: detected)",
+				Destination: &args.From,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "'yaml', 'toml', or 'json' (default: 'yaml')",
+				Destination: &args.Format,
+				Validator: func(format string) error {
+					switch format {
+					case "":
+					case "yaml":
+					case "toml":
+					case "json":
+					default:
+						return errInvalidFormat
+					}
+					return nil
+				},
+			},
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "overwrite existing lefthook config",
+				Destination: &args.Force,
+			},
+			&cli.BoolFlag{
+				Name:        "cleanup",
+				Usage:       "remove the hooks installed by the migrated tool",
+				Destination: &args.Cleanup,
+			},
+			&cli.BoolFlag{
+				Name:        "verbose",
+				Aliases:     []string{"v"},
+				Destination: &verbose,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			l, err := command.NewLefthook(verbose, "auto")
+			if err != nil {
+				return err
+			}
+
+			return l.Migrate(ctx, args)
+		},
+		ShellComplete: func(ctx context.Context, cmd *cli.Command) {
+			command.ShellCompleteFlags(cmd)
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/command"
+)
+
+func remotes() *cli.Command {
+	var verbose bool
+
+	return &cli.Command{
+		Name:  "remotes",
+		Usage: "inspect and update remote configs",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "verbose",
+				Aliases:     []string{"v"},
+				Destination: &verbose,
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "show the remotes with their commits and fetch times",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					l, err := command.NewLefthook(verbose, "auto")
+					if err != nil {
+						return err
+					}
+
+					return l.RemotesList(ctx)
+				},
+			},
+			{
+				Name:      "update",
+				Usage:     "fetch the latest version of the remotes and pin it in lefthook.lock",
+				ArgsUsage: "[name...]",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					l, err := command.NewLefthook(verbose, "auto")
+					if err != nil {
+						return err
+					}
+
+					return l.RemotesUpdate(ctx, command.RemotesArgs{Names: cmd.Args().Slice()})
+				},
+			},
+			{
+				Name:  "prune",
+				Usage: "remove fetched remotes which are not configured anymore",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					l, err := command.NewLefthook(verbose, "auto")
+					if err != nil {
+						return err
+					}
+
+					return l.RemotesPrune(ctx)
+				},
+			},
+			{
+				Name:      "diff",
+				Usage:     "show how the merged config changes after updating the remotes",
+				ArgsUsage: "[name...]",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					l, err := command.NewLefthook(verbose, "auto")
+					if err != nil {
+						return err
+					}
+
+					return l.RemotesDiff(ctx, command.RemotesArgs{Names: cmd.Args().Slice()})
+				},
+			},
+		},
+		ShellComplete: func(ctx context.Context, cmd *cli.Command) {
+			command.ShellCompleteFlags(cmd)
+		},
+	}
+}
@@ -63,6 +63,16 @@ func run() *cli.Command {
 				Usage:       "overwrite file templates with files",
 				Destination: &args.Files,
 			},
+			&cli.StringFlag{
+				Name:        "from-ref",
+				Usage:       "base ref for {changed_files} (default: $LEFTHOOK_BASE_REF)",
+				Destination: &args.FromRef,
+			},
+			&cli.StringFlag{
+				Name:        "to-ref",
+				Usage:       "target ref for {changed_files} (default: HEAD)",
+				Destination: &args.ToRef,
+			},
 			&cli.BoolFlag{
 				Name:        "force",
 				Aliases:     []string{"f"},
@@ -84,11 +94,42 @@ func run() *cli.Command {
 				Usage:       "ignore 'stage_fixed: true' setting",
 				Destination: &args.NoStageFixed,
 			},
+			&cli.BoolFlag{
+				Name:        "fail-fast",
+				Usage:       "cancel remaining jobs after the first failure",
+				Destination: &args.FailFast,
+			},
+			&cli.IntFlag{
+				Name:        "jobs",
+				Aliases:     []string{"j"},
+				Usage:       "maximum number of jobs running in parallel (default: number of CPUs)",
+				Destination: &args.MaxParallel,
+			},
+			&cli.StringFlag{
+				Name:        "report",
+				Usage:       "write a JSON report of the run to the file",
+				Destination: &args.Report,
+			},
+			&cli.StringFlag{
+				Name:        "junit",
+				Usage:       "write a JUnit XML report of the run to the file",
+				Destination: &args.JUnit,
+			},
+			&cli.StringFlag{
+				Name:        "trace",
+				Usage:       "write a Chrome trace of the run to the file",
+				Destination: &args.Trace,
+			},
 			&cli.BoolFlag{
 				Name:        "no-tty",
 				Usage:       "act as if no TTY is connected",
 				Destination: &args.NoTTY,
 			},
+			&cli.BoolFlag{
+				Name:        "no-cache",
+				Usage:       "ignore cached results of the jobs",
+				Destination: &args.NoCache,
+			},
 			&cli.BoolFlag{
 				Name:        "skip-lfs",
 				Usage:       "do not run LFS hooks",
@@ -10,7 +10,7 @@ import (
 
 func uninstall() *cli.Command {
 	var args command.UninstallArgs
-	var verbose bool
+	var verbose, global bool
 
 	return &cli.Command{
 		Name:  "uninstall",
@@ -32,8 +32,17 @@ func uninstall() *cli.Command {
 				Usage:       "remove lefthook configs",
 				Destination: &args.RemoveConfig,
 			},
+			&cli.BoolFlag{
+				Name:        "global",
+				Usage:       "remove hooks installed with install --global",
+				Destination: &global,
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if global {
+				return command.NewGlobalLefthook(verbose, "auto").UninstallGlobal(ctx)
+			}
+
 			l, err := command.NewLefthook(verbose, "auto")
 			if err != nil {
 				return err
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/command"
+)
+
+func watch() *cli.Command {
+	var args command.WatchArgs
+	var colors string
+
+	return &cli.Command{
+		Name:      "watch",
+		Usage:     "run the jobs of a hook on file changes",
+		UsageText: "lefthook watch <hook-name> [options]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "verbose",
+				Aliases:     []string{"v"},
+				Usage:       "enable debug logs",
+				Destination: &args.Verbose,
+			},
+			&cli.StringFlag{
+				Name:        "colors",
+				Usage:       "on, off, or auto (default: auto)",
+				Destination: &colors,
+				Value:       "auto",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			l, err := command.NewLefthook(args.Verbose, colors)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Args().Len() < 1 {
+				return errors.New("hook name missing")
+			}
+
+			args.Hook = cmd.Args().Get(0)
+			return l.Watch(ctx, args)
+		},
+		ShellComplete: func(ctx context.Context, cmd *cli.Command) {
+			command.ShellCompleteFlags(cmd)
+			command.ShellCompleteHookNames()
+		},
+	}
+}
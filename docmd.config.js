@@ -151,6 +151,10 @@ module.exports = {
           title: "assert_lefthook_installed",
           path: "/configuration/assert_lefthook_installed"
         },
+        {
+          title: "chain_existing_hooks",
+          path: "/configuration/chain_existing_hooks"
+        },
         {
           title: "colors",
           path: "/configuration/colors"
@@ -171,6 +175,10 @@ module.exports = {
           title: "lefthook",
           path: "/configuration/lefthook"
         },
+        {
+          title: "lock_mismatch",
+          path: "/configuration/lock_mismatch"
+        },
         {
           title: "min_version",
           path: "/configuration/min_version"
@@ -199,6 +207,18 @@ module.exports = {
               title: "git_url",
               path: "/configuration/git_url"
             },
+            {
+              title: "archive_url",
+              path: "/configuration/archive_url"
+            },
+            {
+              title: "sha256",
+              path: "/configuration/sha256"
+            },
+            {
+              title: "path",
+              path: "/configuration/path"
+            },
             {
               title: "ref",
               path: "/configuration/ref"
@@ -217,6 +237,10 @@ module.exports = {
             }
           ]
         },
+        {
+          title: "report",
+          path: "/configuration/report"
+        },
         {
           title: "source_dir",
           path: "/configuration/source_dir"
@@ -241,10 +265,18 @@ module.exports = {
               title: "parallel",
               path: "/configuration/parallel"
             },
+            {
+              title: "max_parallel",
+              path: "/configuration/max_parallel"
+            },
             {
               title: "piped",
               path: "/configuration/piped"
             },
+            {
+              title: "fail_fast",
+              path: "/configuration/fail_fast"
+            },
             {
               title: "follow",
               path: "/configuration/follow"
@@ -273,6 +305,10 @@ module.exports = {
               title: "only",
               path: "/configuration/only"
             },
+            {
+              title: "if",
+              path: "/configuration/if"
+            },
             {
               title: "skip",
               path: "/configuration/skip"
@@ -305,6 +341,10 @@ module.exports = {
                   title: "args",
                   path: "/configuration/args"
                 },
+                {
+                  title: "lint_commit_msg",
+                  path: "/configuration/lint_commit_msg"
+                },
                 {
                   title: "group",
                   collapsible: true,
@@ -314,16 +354,36 @@ module.exports = {
                       title: "parallel",
                       path: "/configuration/parallel"
                     },
+                    {
+                      title: "max_parallel",
+                      path: "/configuration/max_parallel"
+                    },
                     {
                       title: "piped",
                       path: "/configuration/piped"
                     },
+                    {
+                      title: "fail_fast",
+                      path: "/configuration/fail_fast"
+                    },
                     {
                       title: "jobs",
                       path: "/configuration/jobs"
                     }
                   ]
                 },
+                {
+                  title: "needs",
+                  path: "/configuration/needs"
+                },
+                {
+                  title: "locks",
+                  path: "/configuration/locks"
+                },
+                {
+                  title: "outputs",
+                  path: "/configuration/outputs"
+                },
                 {
                   title: "skip",
                   path: "/configuration/skip"
@@ -332,6 +392,10 @@ module.exports = {
                   title: "only",
                   path: "/configuration/only"
                 },
+                {
+                  title: "if",
+                  path: "/configuration/if"
+                },
                 {
                   title: "tags",
                   path: "/configuration/tags"
@@ -352,6 +416,10 @@ module.exports = {
                   title: "env",
                   path: "/configuration/env"
                 },
+                {
+                  title: "matrix",
+                  path: "/configuration/matrix"
+                },
                 {
                   title: "root",
                   path: "/configuration/root"
@@ -364,6 +432,22 @@ module.exports = {
                   title: "fail_text",
                   path: "/configuration/fail_text"
                 },
+                {
+                  title: "retries",
+                  path: "/configuration/retries"
+                },
+                {
+                  title: "retry_delay",
+                  path: "/configuration/retry_delay"
+                },
+                {
+                  title: "allow_failure",
+                  path: "/configuration/allow_failure"
+                },
+                {
+                  title: "cache",
+                  path: "/configuration/cache"
+                },
                 {
                   title: "stage_fixed",
                   path: "/configuration/stage_fixed"
@@ -518,6 +602,11 @@ module.exports = {
           icon: "chevron-right",
           path: "/usage/commands/run"
         },
+        {
+          title: "lefthook watch",
+          icon: "chevron-right",
+          path: "/usage/commands/watch"
+        },
         {
           title: "lefthook add",
           icon: "chevron-right",
@@ -533,11 +622,26 @@ module.exports = {
           icon: "chevron-right",
           path: "/usage/commands/dump"
         },
+        {
+          title: "lefthook remotes",
+          icon: "chevron-right",
+          path: "/usage/commands/remotes"
+        },
+        {
+          title: "lefthook cache",
+          icon: "chevron-right",
+          path: "/usage/commands/cache"
+        },
         {
           title: "lefthook check-install",
           icon: "chevron-right",
           path: "/usage/commands/check-install"
         },
+        {
+          title: "lefthook migrate",
+          icon: "chevron-right",
+          path: "/usage/commands/migrate"
+        },
         {
           title: "lefthook self-update",
           icon: "chevron-right",
@@ -564,6 +668,14 @@ module.exports = {
               title: "LEFTHOOK_CONFIG",
               path: "/usage/envs/LEFTHOOK_CONFIG"
             },
+            {
+              title: "LEFTHOOK_MAX_PARALLEL",
+              path: "/usage/envs/LEFTHOOK_MAX_PARALLEL"
+            },
+            {
+              title: "LEFTHOOK_BASE_REF",
+              path: "/usage/envs/LEFTHOOK_BASE_REF"
+            },
             {
               title: "LEFTHOOK_EXCLUDE",
               path: "/usage/envs/LEFTHOOK_EXCLUDE"
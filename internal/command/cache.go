@@ -0,0 +1,14 @@
+package command
+
+import "context"
+
+// ClearCache removes the cached results of the jobs.
+func (l *Lefthook) ClearCache(_ctx context.Context) error {
+	if err := l.fs.RemoveAll(l.repo.CacheFolder()); err != nil {
+		return err
+	}
+
+	l.logger.Info("Cache cleared")
+
+	return nil
+}
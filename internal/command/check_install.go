@@ -2,7 +2,12 @@ package command
 
 import (
 	"context"
-	"os"
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
 )
 
 type installationStatus int
@@ -12,17 +17,17 @@ const (
 	notInstalled
 )
 
+// ErrNotInstalled is returned by the checks when the hooks are not installed or stale.
+var ErrNotInstalled = errors.New("hooks are not installed")
+
 func (l *Lefthook) CheckInstall(_ctx context.Context) error {
 	check, err := l.checkInstall()
 	if err != nil {
 		return err
 	}
 
-	switch check {
-	case installed:
-		os.Exit(0)
-	case notInstalled:
-		os.Exit(1)
+	if check == notInstalled {
+		return ErrNotInstalled
 	}
 
 	return nil
@@ -38,6 +43,16 @@ func (l *Lefthook) checkInstall() (installationStatus, error) {
 		return notInstalled, err
 	}
 
+	// Global hooks run lefthook in every repository with a config
+	if l.isGlobalHooksDir(l.repo.HooksPath) {
+		hooks := slices.Collect(maps.Keys(cfg.Hooks))
+		if !l.globalHooksInstalled(l.repo.HooksPath, hooks) {
+			return notInstalled, nil
+		}
+
+		return installed, nil
+	}
+
 	ok, _ := l.checkHooksSynchronized(cfg)
 	if !ok {
 		return notInstalled, nil
@@ -45,3 +60,19 @@ func (l *Lefthook) checkInstall() (installationStatus, error) {
 
 	return installed, nil
 }
+
+// globalHooksInstalled checks that the global hooks directory has the hooks
+// installed with `lefthook install --global`. Unknown Git hooks are ignored.
+func (l *Lefthook) globalHooksInstalled(hooksDir string, hooks []string) bool {
+	for _, hook := range hooks {
+		if _, ok := config.AvailableHooks[hook]; !ok {
+			continue
+		}
+
+		if !l.isGlobalHook(filepath.Join(hooksDir, hook)) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -2,38 +2,62 @@ package command
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/evilmartians/lefthook/v2/internal/config"
 )
 
+var errExplainFormat = errors.New("--explain supports only 'yaml' format")
+
 type DumpArgs struct {
-	Format string
+	Format  string
+	Explain bool
 }
 
 func (l *Lefthook) Dump(_ctx context.Context, args DumpArgs) error {
+	if args.Explain {
+		return l.dumpExplained(args)
+	}
+
 	cfg, err := l.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("couldn't load config: %w", err)
 	}
 
-	var format config.DumpFormat
+	if err := cfg.Dump(dumpFormat(args.Format), os.Stdout); err != nil {
+		return fmt.Errorf("couldn't dump config: %w", err)
+	}
 
-	switch args.Format {
-	case "yaml":
-		format = config.YAMLFormat
-	case "json":
-		format = config.JSONFormat
-	case "toml":
-		format = config.TOMLFormat
-	default:
-		format = config.YAMLFormat
+	return nil
+}
+
+// dumpExplained prints the config annotated with the files that set the values.
+func (l *Lefthook) dumpExplained(args DumpArgs) error {
+	if dumpFormat(args.Format) != config.YAMLFormat {
+		return errExplainFormat
 	}
 
-	if err := cfg.Dump(format, os.Stdout); err != nil {
+	cfg, explanation, err := config.NewLoader(l.repo, l.logger).Explain()
+	if err != nil {
+		return fmt.Errorf("couldn't load config: %w", err)
+	}
+
+	if err := cfg.DumpExplained(explanation, os.Stdout); err != nil {
 		return fmt.Errorf("couldn't dump config: %w", err)
 	}
 
 	return nil
 }
+
+func dumpFormat(name string) config.DumpFormat {
+	switch name {
+	case "json":
+		return config.JSONFormat
+	case "toml":
+		return config.TOMLFormat
+	default:
+		return config.YAMLFormat
+	}
+}
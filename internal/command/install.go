@@ -20,6 +20,7 @@ import (
 	"github.com/evilmartians/lefthook/v2/internal/git"
 	"github.com/evilmartians/lefthook/v2/internal/logger"
 	"github.com/evilmartians/lefthook/v2/internal/templates"
+	"github.com/evilmartians/lefthook/v2/internal/version"
 )
 
 const (
@@ -39,6 +40,13 @@ var (
 type InstallArgs struct {
 	Force          bool
 	ResetHooksPath bool
+
+	// Chain makes the hooks run the existing hooks saved with .old suffix.
+	Chain bool
+
+	// Global installs the hooks for all repositories, Template makes it use
+	// init.templateDir instead of core.hooksPath.
+	Global, Template bool
 }
 
 func (l *Lefthook) Install(ctx context.Context, args InstallArgs, hooks []string) error {
@@ -49,9 +57,9 @@ func (l *Lefthook) Install(ctx context.Context, args InstallArgs, hooks []string
 
 	var remotesSynced bool
 	for _, remote := range cfg.Remotes {
-		if remote.Configured() {
-			if err = l.syncRemote(remote.GitURL, remote.Ref, args.Force); err != nil {
-				l.logger.Warnf("Couldn't sync from %s. Will continue anyway: %s", remote.GitURL, err)
+		if remote.Configured() && remote.Fetched() {
+			if err = l.syncLockedRemote(remote, cfg.SourceDir, args.Force); err != nil {
+				l.logger.Warnf("Couldn't sync from %s. Will continue anyway: %s", remote.Source(), err)
 				continue
 			}
 
@@ -67,6 +75,14 @@ func (l *Lefthook) Install(ctx context.Context, args InstallArgs, hooks []string
 		}
 	}
 
+	if err = config.NewLoader(l.repo, l.logger).VerifyLock(cfg); err != nil {
+		return err
+	}
+
+	if err = l.updateLock(cfg); err != nil {
+		return fmt.Errorf("failed to update %s: %w", config.LockFileName, err)
+	}
+
 	if err = l.installHooks(cfg, hooks, args); err != nil {
 		return err
 	}
@@ -98,7 +114,7 @@ func (l *Lefthook) installHooks(cfg *config.Config, hooks []string, args Install
 		return err
 	}
 
-	return l.createHooksIfNeeded(cfg, hooks, args.Force)
+	return l.createHooksIfNeeded(cfg, hooks, args)
 }
 
 func (l *Lefthook) readOrCreateConfig() (*config.Config, error) {
@@ -111,7 +127,8 @@ func (l *Lefthook) readOrCreateConfig() (*config.Config, error) {
 		}
 	}
 
-	return l.LoadConfig()
+	// Remotes are verified against lefthook.lock after they are synced
+	return config.NewLoader(l.repo, l.logger).SkipLock().Load()
 }
 
 func (l *Lefthook) configExists(path string) bool {
@@ -161,13 +178,21 @@ func (l *Lefthook) syncHooks(cfg *config.Config, fetchRemotes bool) (*config.Con
 
 	//nolint:nestif
 	if fetchRemotes {
+		lock, err := config.ReadLock(l.fs, l.repo.RootPath)
+		if err != nil {
+			return nil, err
+		}
+
 		fetchedRemotes := make(map[string]struct{})
 		for _, remote := range cfg.Remotes {
 			if !remote.Configured() {
 				continue
 			}
-			if l.shouldRefetch(remote) {
-				if serr := l.syncRemote(remote.GitURL, remote.Ref, false); serr != nil {
+			if l.shouldRefetch(remote) || l.lockOutdated(lock.Find(remote), remote, cfg.SourceDir) {
+				serr := l.syncLockedRemote(remote, cfg.SourceDir, false)
+				if serr != nil && len(remote.GitURL) == 0 {
+					l.logger.Warnf("Couldn't sync from %s. Will continue with old version.", remote.Source())
+				} else if serr != nil {
 					ref, err := l.findAvailableRemoteRef(remote.GitURL)
 					if err != nil {
 						l.logger.Warnf("Couldn't sync from %s. Will continue without that remote.", remote.GitURL)
@@ -186,12 +211,10 @@ func (l *Lefthook) syncHooks(cfg *config.Config, fetchRemotes bool) (*config.Con
 				remotesSynced = true
 			}
 
-			fetchedRemotes[l.repo.RemoteFolder(remote.GitURL, remote.Ref)] = struct{}{}
+			fetchedRemotes[remote.Folder(l.repo)] = struct{}{}
 		}
 
 		if remotesSynced {
-			var err error
-
 			// Reread the config file with synced remotes
 			cfg, err = l.reloadConfig(cfg)
 			if err != nil {
@@ -200,21 +223,9 @@ func (l *Lefthook) syncHooks(cfg *config.Config, fetchRemotes bool) (*config.Con
 		}
 
 		if len(fetchedRemotes) > 0 {
-			// Delete stale remotes
-			entries, err := afero.ReadDir(l.fs, l.repo.RemotesFolder())
-			if err != nil {
+			if _, err := l.pruneRemotes(fetchedRemotes); err != nil {
 				return nil, err
 			}
-			for _, entry := range entries {
-				remotePath := filepath.Join(l.repo.RemotesFolder(), entry.Name())
-				if _, ok := fetchedRemotes[remotePath]; !ok {
-					l.logger.Debug("Removing stale remote: ", remotePath)
-
-					if err = l.fs.RemoveAll(remotePath); err != nil {
-						l.logger.Error("failed to drop stale remote path: ", remotePath)
-					}
-				}
-			}
 		}
 	}
 
@@ -232,6 +243,9 @@ func (l *Lefthook) syncHooks(cfg *config.Config, fetchRemotes bool) (*config.Con
 }
 
 func (l *Lefthook) shouldRefetch(remote *config.Remote) bool {
+	if !remote.Fetched() {
+		return false
+	}
 	if remote.Refetch || remote.RefetchFrequency == "always" {
 		return true
 	}
@@ -239,9 +253,7 @@ func (l *Lefthook) shouldRefetch(remote *config.Remote) bool {
 		return false
 	}
 
-	var lastFetchTime time.Time
-	remotePath := l.repo.RemoteFolder(remote.GitURL, remote.Ref)
-	info, err := l.fs.Stat(filepath.Join(remotePath, ".git", "FETCH_HEAD"))
+	lastFetchTime, err := l.remoteFetchTime(remote)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return true
@@ -255,7 +267,6 @@ func (l *Lefthook) shouldRefetch(remote *config.Remote) bool {
 		return false
 	}
 
-	lastFetchTime = info.ModTime()
 	timedelta, err := time.ParseDuration(remote.RefetchFrequency)
 	if err != nil {
 		l.logger.Warnf("Couldn't parse refetch frequency %s. Will continue anyway: %s", remote.RefetchFrequency, err)
@@ -265,6 +276,21 @@ func (l *Lefthook) shouldRefetch(remote *config.Remote) bool {
 	return time.Now().After(lastFetchTime.Add(timedelta))
 }
 
+// remoteFetchTime returns the time the remote was fetched last time.
+func (l *Lefthook) remoteFetchTime(remote *config.Remote) (time.Time, error) {
+	fetchMarker := filepath.Join(remote.Folder(l.repo), ".git", "FETCH_HEAD")
+	if len(remote.ArchiveURL) > 0 {
+		fetchMarker = filepath.Join(remote.Folder(l.repo), git.ArchiveMarker)
+	}
+
+	info, err := l.fs.Stat(fetchMarker)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
 func (l *Lefthook) findAvailableRemoteRef(url string) (string, error) {
 	entries, err := afero.ReadDir(l.fs, l.repo.RemotesFolder())
 	if err != nil {
@@ -286,7 +312,7 @@ func (l *Lefthook) findAvailableRemoteRef(url string) (string, error) {
 	return "", errors.New("not found")
 }
 
-func (l *Lefthook) createHooksIfNeeded(cfg *config.Config, hooks []string, force bool) error {
+func (l *Lefthook) createHooksIfNeeded(cfg *config.Config, hooks []string, args InstallArgs) error {
 	onlyHooks := make(map[string]struct{})
 	for _, hook := range hooks {
 		onlyHooks[hook] = struct{}{}
@@ -317,10 +343,16 @@ func (l *Lefthook) createHooksIfNeeded(cfg *config.Config, hooks []string, force
 			continue
 		}
 
-		if err = l.cleanHook(hook, force); err != nil {
+		chain := args.Chain || cfg.ChainExistingHooks
+
+		if err = l.cleanHook(hook, args.Force); err != nil {
 			return fmt.Errorf("could not replace the hook: %w", err)
 		}
 
+		if !chain {
+			l.warnOldHook(hook)
+		}
+
 		if _, ok := config.AvailableHooks[hook]; !ok && !cfg.InstallNonGitHooks {
 			continue
 		}
@@ -332,6 +364,7 @@ func (l *Lefthook) createHooksIfNeeded(cfg *config.Config, hooks []string, force
 			AssertLefthookInstalled: cfg.AssertLefthookInstalled,
 			Roots:                   roots,
 			LefthookPath:            cfg.Lefthook,
+			Chain:                   chain,
 		}
 		if err = l.addHook(hook, templateArgs); err != nil {
 			return fmt.Errorf("could not add the hook: %w", err)
@@ -364,6 +397,16 @@ func (l *Lefthook) createHooksIfNeeded(cfg *config.Config, hooks []string, force
 	return nil
 }
 
+// warnOldHook tells that the hook saved with .old suffix doesn't run.
+func (l *Lefthook) warnOldHook(hook string) {
+	exists, err := afero.Exists(l.fs, filepath.Join(l.repo.HooksPath, hook+oldHookPostfix))
+	if err != nil || !exists {
+		return
+	}
+
+	l.logger.Warnf("%s%s is left behind and doesn't run, use `lefthook install --chain` or `chain_existing_hooks: true` to run it", hook, oldHookPostfix)
+}
+
 func collectRoots(cfg *config.Config) []string {
 	rootsMap := make(map[string]struct{})
 	for _, hook := range cfg.Hooks {
@@ -515,6 +558,15 @@ func (l *Lefthook) ensureHooksPathUnset(force, resetHooksPath bool) error {
 	local, global := l.getHooksPathConfig()
 	defaultHooksPath := filepath.Join(l.repo.RootPath, ".git", "hooks")
 
+	// Global hooks installed by lefthook run lefthook in this repository,
+	// the hooks are installed into the repository's hooks directory.
+	if len(global) > 0 && l.isGlobalHooksDir(expandHome(global)) {
+		global = ""
+		if len(local) == 0 {
+			return l.useRepoHooksPath()
+		}
+	}
+
 	// Ignore if hooks path is equal to default git hooks path
 	hasLocal := len(local) > 0 && filepath.Clean(local) != filepath.Clean(defaultHooksPath)
 	hasGlobal := len(global) > 0
@@ -550,6 +602,20 @@ func (l *Lefthook) ensureHooksPathUnset(force, resetHooksPath bool) error {
 	return nil
 }
 
+// useRepoHooksPath sets the hooks path to the hooks directory of the
+// repository ignoring global core.hooksPath.
+func (l *Lefthook) useRepoHooksPath() error {
+	commonDir, err := l.repo.Git.Cmd([]string{"git", "rev-parse", "--path-format=absolute", "--git-common-dir"})
+	if err != nil {
+		return err
+	}
+
+	l.repo.HooksPath = filepath.Join(commonDir, "hooks")
+	l.logger.Warnf("Global hooks are active, Git runs them instead of the hooks in '%s'", l.repo.HooksPath)
+
+	return nil
+}
+
 // formatHooksPathError formats an error message for core.hooksPath conflicts.
 func formatHooksPathError(local, global string) string {
 	var errMsg strings.Builder
@@ -615,12 +681,12 @@ func (l *Lefthook) unsetHooksPathConfig(local, global string) error {
 }
 
 // syncRemote clones or pulls the latest changes for a git repository that was
-// specified as a remote config repository. If successful, the path to the root
-// of the repository will be returned.
-func (l *Lefthook) syncRemote(url, ref string, force bool) error {
+// specified as a remote config repository, or downloads the remote archive.
+// The remote is kept at the locked commit when it's set.
+func (l *Lefthook) syncRemote(remote *config.Remote, locked *config.LockedRemote, force bool) (err error) {
 	remotesPath := l.repo.RemotesFolder()
 
-	err := l.repo.Fs.MkdirAll(remotesPath, remotesFolderMode)
+	err = l.repo.Fs.MkdirAll(remotesPath, remotesFolderMode)
 	if err != nil && !errors.Is(err, os.ErrExist) {
 		return err
 	}
@@ -630,6 +696,29 @@ func (l *Lefthook) syncRemote(url, ref string, force bool) error {
 	defer l.logger.Spinner.Stop()
 	defer l.logger.Spinner.RemoveName("fetching remotes")
 
+	var commit string
+	if locked != nil {
+		commit = locked.Commit
+	}
+
+	if len(remote.ArchiveURL) > 0 {
+		return l.syncArchive(remote, commit, force)
+	}
+
+	url, ref := remote.GitURL, remote.Ref
+	if version.IsRange(ref) {
+		if ref, err = l.resolveRemoteRange(remote, locked); err != nil {
+			return err
+		}
+
+		// Only the resolved tag must be cached, the config is loaded from it
+		defer func() {
+			if err == nil {
+				l.removeResolvedRemotes(remote, ref)
+			}
+		}()
+	}
+
 	directoryName := git.RemoteDirectoryName(url, ref)
 	remotePath := filepath.Join(remotesPath, directoryName)
 
@@ -640,6 +729,10 @@ func (l *Lefthook) syncRemote(url, ref string, force bool) error {
 		}
 	} else {
 		_, err = l.repo.Fs.Stat(remotePath)
+		if err == nil && len(commit) > 0 {
+			l.logger.Debugf("Checking out locked commit %s: %s", commit, remotePath)
+			return l.repo.CheckoutRemote(remotePath, commit)
+		}
 		if err == nil {
 			l.logger.Debugf("Updating remote config repository: %s", remotePath)
 			return l.repo.UpdateRemote(remotePath, ref)
@@ -647,5 +740,181 @@ func (l *Lefthook) syncRemote(url, ref string, force bool) error {
 	}
 
 	l.logger.Debugf("Cloning remote config repository: %v/%v", remotePath, directoryName)
-	return l.repo.CloneRemote(remotesPath, directoryName, url, ref)
+	if err = l.repo.CloneRemote(remotesPath, directoryName, url, ref); err != nil {
+		return err
+	}
+
+	if len(commit) > 0 {
+		l.logger.Debugf("Checking out locked commit %s: %s", commit, remotePath)
+		if err = l.repo.CheckoutRemote(remotePath, commit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveRemoteRange returns the highest tag matching the version range of
+// the remote. The tag from lefthook.lock is used if the remote is locked.
+func (l *Lefthook) resolveRemoteRange(remote *config.Remote, locked *config.LockedRemote) (string, error) {
+	if locked != nil && len(locked.Tag) > 0 {
+		return locked.Tag, nil
+	}
+
+	tags, err := l.repo.RemoteTags(remote.GitURL)
+	if err != nil {
+		return "", fmt.Errorf("couldn't list tags: %w", err)
+	}
+
+	tag, err := version.MaxSatisfying(remote.Ref, tags)
+	if err != nil {
+		return "", err
+	}
+
+	l.logger.Debugf("Resolved %s %s to %s", remote.GitURL, remote.Ref, tag)
+
+	return tag, nil
+}
+
+// removeResolvedRemotes removes the copies of the remote fetched at the other
+// tags matching its version range.
+func (l *Lefthook) removeResolvedRemotes(remote *config.Remote, keep string) {
+	for _, ref := range l.repo.CachedRemoteRefs(remote.GitURL) {
+		if ref == keep {
+			continue
+		}
+
+		if _, err := version.MaxSatisfying(remote.Ref, []string{ref}); err != nil {
+			continue
+		}
+
+		remotePath := l.repo.RemoteFolder(remote.GitURL, ref)
+		l.logger.Debug("Removing outdated remote: ", remotePath)
+		if err := l.fs.RemoveAll(remotePath); err != nil {
+			l.logger.Warnf("Failed to remove outdated remote %s: %s", remotePath, err)
+		}
+	}
+}
+
+// syncArchive downloads the remote archive. The archive is not downloaded
+// again if the extracted one has the expected checksum.
+func (l *Lefthook) syncArchive(remote *config.Remote, commit string, force bool) error {
+	remotePath := remote.Folder(l.repo)
+
+	checksum := remote.SHA256
+	if len(checksum) == 0 {
+		checksum = commit
+	}
+
+	if !force && len(checksum) > 0 {
+		current, err := l.repo.ArchiveChecksum(remotePath)
+		if err == nil && strings.EqualFold(current, strings.TrimPrefix(checksum, "sha256:")) {
+			l.logger.Debugf("Remote archive is up to date: %s", remotePath)
+			return nil
+		}
+	}
+
+	l.logger.Debugf("Downloading remote config archive: %s", remote.ArchiveURL)
+	return l.repo.DownloadArchive(remotePath, remote.ArchiveURL, checksum)
+}
+
+// syncLockedRemote syncs the remote keeping it at the commit from lefthook.lock.
+// The remote is fetched again if its files were changed.
+func (l *Lefthook) syncLockedRemote(remote *config.Remote, sourceDir string, force bool) error {
+	lock, err := config.ReadLock(l.fs, l.repo.RootPath)
+	if err != nil {
+		return err
+	}
+
+	locked := lock.Find(remote)
+	if !force && locked != nil {
+		if actual := l.lockRemote(remote, sourceDir); actual != nil && actual.Commit == locked.Commit && actual.Hash != locked.Hash {
+			l.logger.Debugf("Remote %s was changed, fetching it again", remote.Source())
+			force = true
+		}
+	}
+
+	return l.syncRemote(remote, locked, force)
+}
+
+// lockOutdated tells whether the remote checkout differs from the locked one.
+func (l *Lefthook) lockOutdated(locked *config.LockedRemote, remote *config.Remote, sourceDir string) bool {
+	if locked == nil || !remote.Fetched() {
+		return false
+	}
+
+	actual := l.lockRemote(remote, sourceDir)
+
+	return actual != nil && (actual.Commit != locked.Commit || actual.Hash != locked.Hash)
+}
+
+// lockRemote returns the current state of the remote checkout or nil if it
+// isn't fetched.
+func (l *Lefthook) lockRemote(remote *config.Remote, sourceDir string) *config.LockedRemote {
+	if ok, _ := afero.DirExists(l.fs, remote.Folder(l.repo)); !ok {
+		return nil
+	}
+
+	actual, err := config.LockRemote(l.repo, remote, sourceDir)
+	if err != nil {
+		l.logger.Debugf("Couldn't check remote %s: %s", remote.Source(), err)
+		return nil
+	}
+
+	return actual
+}
+
+// updateLock writes lefthook.lock with the checked out remotes. Locked remotes
+// are kept as is, the remotes not configured anymore are dropped.
+func (l *Lefthook) updateLock(cfg *config.Config) error {
+	lock, err := config.ReadLock(l.fs, l.repo.RootPath)
+	if err != nil {
+		return err
+	}
+
+	newLock := &config.Lock{}
+	for _, remote := range cfg.Remotes {
+		if !remote.Configured() || !remote.Fetched() {
+			continue
+		}
+
+		locked := lock.Find(remote)
+		if locked != nil && (len(remote.SHA256) == 0 || strings.EqualFold(locked.Commit, strings.TrimPrefix(remote.SHA256, "sha256:"))) {
+			newLock.Remotes = append(newLock.Remotes, locked)
+			continue
+		}
+
+		if ok, _ := afero.DirExists(l.fs, remote.Folder(l.repo)); !ok {
+			continue
+		}
+
+		locked, err = config.LockRemote(l.repo, remote, cfg.SourceDir)
+		if err != nil {
+			return err
+		}
+
+		l.logger.Debugf("Locking %s at %s", remote.Source(), locked.Commit)
+		newLock.Remotes = append(newLock.Remotes, locked)
+	}
+
+	lockPath := filepath.Join(l.repo.RootPath, config.LockFileName)
+	if len(newLock.Remotes) == 0 {
+		if lock == nil {
+			return nil
+		}
+
+		return l.fs.Remove(lockPath)
+	}
+
+	if lock != nil && slices.EqualFunc(lock.Remotes, newLock.Remotes, func(a, b *config.LockedRemote) bool { return *a == *b }) {
+		return nil
+	}
+
+	if err = newLock.Write(l.fs, l.repo.RootPath); err != nil {
+		return err
+	}
+
+	l.logger.Infof("Updated %s", config.LockFileName)
+
+	return nil
 }
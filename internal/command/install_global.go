@@ -0,0 +1,267 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/templates"
+)
+
+const (
+	globalDirName     = "lefthook"
+	globalHooksDir    = "hooks"
+	globalTemplateDir = "template"
+
+	gitHooksPath   = "core.hooksPath"
+	gitTemplateDir = "init.templateDir"
+)
+
+// Settings keeping the values replaced by `lefthook install --global --force`.
+var globalPreviousSettings = map[string]string{
+	gitHooksPath:   "lefthook.previousHooksPath",
+	gitTemplateDir: "lefthook.previousTemplateDir",
+}
+
+var errGlobalNotInstalled = errors.New("global hooks are not installed")
+
+// InstallGlobal installs the hooks running lefthook in every repository which
+// has lefthook config. The hooks are installed into a directory configured as
+// global core.hooksPath, or init.templateDir in template mode.
+func (l *Lefthook) InstallGlobal(_ctx context.Context, args InstallArgs) error {
+	setting, dir, err := globalSetting(args.Template)
+	if err != nil {
+		return err
+	}
+
+	current := l.globalGitConfig(setting)
+	if len(current) > 0 && !samePath(current, dir) && !l.isGlobalHooksDir(globalSettingHooksPath(setting, current)) {
+		if !args.Force {
+			return fmt.Errorf("%s is set globally to '%s', use --force to replace it", setting, current)
+		}
+
+		l.logger.Warnf("%s is set globally to '%s', replacing", setting, current)
+		if _, err = l.repo.Git.Cmd([]string{"git", "config", "--global", globalPreviousSettings[setting], current}); err != nil {
+			return fmt.Errorf("failed to save global %s: %w", setting, err)
+		}
+	}
+
+	hooksDir := globalSettingHooksPath(setting, dir)
+	if err = l.fs.MkdirAll(hooksDir, hooksDirMode); err != nil {
+		return err
+	}
+
+	templateArgs := templates.Args{
+		Global:      true,
+		ConfigFiles: configFileNames(),
+	}
+	for _, hook := range slices.Sorted(maps.Keys(config.AvailableHooks)) {
+		hookPath := filepath.Join(hooksDir, hook)
+		if err = afero.WriteFile(l.fs, hookPath, templates.Hook(hook, templateArgs), hookFileMode); err != nil {
+			return fmt.Errorf("could not add the hook: %w", err)
+		}
+	}
+
+	if _, err = l.repo.Git.Cmd([]string{"git", "config", "--global", setting, dir}); err != nil {
+		return fmt.Errorf("failed to set global %s: %w", setting, err)
+	}
+
+	l.logger.Infof("Installed global hooks: %s", hooksDir)
+	l.logger.Infof("Global %s is set to '%s'", setting, dir)
+	if args.Template {
+		l.logger.Info("The hooks will be copied into new repositories, run `git init` in existing repositories to copy them")
+	}
+
+	return nil
+}
+
+// UninstallGlobal removes the global hooks and unsets the Git settings
+// pointing to them, or restores the values replaced by the installation.
+// Other hooks in the directories are kept.
+func (l *Lefthook) UninstallGlobal(_ctx context.Context) error {
+	var found bool
+	for _, setting := range []string{gitHooksPath, gitTemplateDir} {
+		current := l.globalGitConfig(setting)
+		if len(current) == 0 {
+			continue
+		}
+
+		hooksDir := globalSettingHooksPath(setting, current)
+		if !l.isGlobalHooksDir(hooksDir) {
+			continue
+		}
+
+		found = true
+		if err := l.deleteGlobalHooks(hooksDir); err != nil {
+			return err
+		}
+
+		if err := l.restoreGlobalSetting(setting); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return errGlobalNotInstalled
+	}
+
+	return nil
+}
+
+// CheckGlobalInstall returns ErrNotInstalled if the global hooks are not
+// installed or some of them are missing.
+func (l *Lefthook) CheckGlobalInstall(_ctx context.Context) error {
+	hooksDir := l.globalHooksPath()
+	if len(hooksDir) == 0 || !l.globalHooksInstalled(hooksDir, slices.Collect(maps.Keys(config.AvailableHooks))) {
+		return ErrNotInstalled
+	}
+
+	return nil
+}
+
+// globalHooksPath returns the directory with the global hooks configured in
+// core.hooksPath or init.templateDir.
+func (l *Lefthook) globalHooksPath() string {
+	for _, setting := range []string{gitHooksPath, gitTemplateDir} {
+		current := l.globalGitConfig(setting)
+		if len(current) == 0 {
+			continue
+		}
+
+		if hooksDir := globalSettingHooksPath(setting, current); l.isGlobalHooksDir(hooksDir) {
+			return hooksDir
+		}
+	}
+
+	return ""
+}
+
+func (l *Lefthook) deleteGlobalHooks(hooksDir string) error {
+	files, err := afero.ReadDir(l.fs, hooksDir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		hookPath := filepath.Join(hooksDir, file.Name())
+		if !l.isGlobalHook(hookPath) {
+			continue
+		}
+
+		if err = l.fs.Remove(hookPath); err != nil {
+			return err
+		}
+		l.logger.Debugf("%s removed", hookPath)
+	}
+
+	l.logger.Infof("Removed global hooks: %s", hooksDir)
+
+	return nil
+}
+
+// restoreGlobalSetting sets the value saved before the installation, or unsets
+// the setting if there was no value.
+func (l *Lefthook) restoreGlobalSetting(setting string) error {
+	previousSetting := globalPreviousSettings[setting]
+	previous, _ := l.repo.Git.Cmd([]string{"git", "config", "--global", previousSetting})
+	if len(previous) == 0 {
+		if _, err := l.repo.Git.Cmd([]string{"git", "config", "--global", "--unset-all", setting}); err != nil {
+			return fmt.Errorf("failed to unset global %s: %w", setting, err)
+		}
+		l.logger.Infof("Global %s has been unset", setting)
+
+		return nil
+	}
+
+	if _, err := l.repo.Git.Cmd([]string{"git", "config", "--global", setting, previous}); err != nil {
+		return fmt.Errorf("failed to restore global %s: %w", setting, err)
+	}
+	if _, err := l.repo.Git.Cmd([]string{"git", "config", "--global", "--unset-all", previousSetting}); err != nil {
+		return fmt.Errorf("failed to unset global %s: %w", previousSetting, err)
+	}
+	l.logger.Infof("Global %s has been restored to '%s'", setting, previous)
+
+	return nil
+}
+
+func (l *Lefthook) globalGitConfig(setting string) string {
+	value, _ := l.repo.Git.Cmd([]string{"git", "config", "--global", setting})
+	return expandHome(value)
+}
+
+// isGlobalHooksDir tests whether the directory contains the global hooks.
+func (l *Lefthook) isGlobalHooksDir(path string) bool {
+	return l.isGlobalHook(filepath.Join(path, "pre-commit"))
+}
+
+func (l *Lefthook) isGlobalHook(path string) bool {
+	content, err := afero.ReadFile(l.fs, path)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(content, []byte(templates.GlobalHookFingerprint))
+}
+
+// globalSetting returns the Git setting and the directory used for it.
+func globalSetting(template bool) (setting, dir string, err error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", "", err
+	}
+
+	if template {
+		return gitTemplateDir, filepath.Join(configDir, globalDirName, globalTemplateDir), nil
+	}
+
+	return gitHooksPath, filepath.Join(configDir, globalDirName, globalHooksDir), nil
+}
+
+// globalSettingHooksPath returns the hooks directory for the setting value.
+// Git template directory keeps the hooks in hooks/ subdirectory.
+func globalSettingHooksPath(setting, value string) string {
+	if setting == gitTemplateDir {
+		return filepath.Join(value, globalHooksDir)
+	}
+
+	return value
+}
+
+// configFileNames returns all possible names of the main and local configs.
+func configFileNames() []string {
+	names := make([]string, 0, (len(config.MainConfigNames)+len(config.LocalConfigNames))*len(config.Extensions))
+	for _, name := range append(config.MainConfigNames, config.LocalConfigNames...) {
+		for _, extension := range config.Extensions {
+			names = append(names, filepath.ToSlash(name+extension))
+		}
+	}
+
+	return names
+}
+
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, rest)
+}
+
+func samePath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
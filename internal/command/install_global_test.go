@@ -0,0 +1,246 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/templates"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+func TestLefthookInstallGlobal(t *testing.T) {
+	_, hooksDir, err := globalSetting(false)
+	assert.NoError(t, err)
+	_, templateDir, err := globalSetting(true)
+	assert.NoError(t, err)
+
+	foreignDir, err := filepath.Abs("custom-hooks")
+	assert.NoError(t, err)
+
+	for n, tt := range [...]struct {
+		name         string
+		args         InstallArgs
+		git          []cmdtest.Out
+		wantErr      string
+		wantHooksDir string
+	}{
+		{
+			name: "core.hooksPath",
+			args: InstallArgs{Global: true},
+			git: []cmdtest.Out{
+				{Command: "git config --global core.hooksPath"},
+				{Command: "git config --global core.hooksPath " + hooksDir},
+			},
+			wantHooksDir: hooksDir,
+		},
+		{
+			name: "init.templateDir",
+			args: InstallArgs{Global: true, Template: true},
+			git: []cmdtest.Out{
+				{Command: "git config --global init.templateDir"},
+				{Command: "git config --global init.templateDir " + templateDir},
+			},
+			wantHooksDir: filepath.Join(templateDir, "hooks"),
+		},
+		{
+			name: "foreign core.hooksPath",
+			args: InstallArgs{Global: true},
+			git: []cmdtest.Out{
+				{Command: "git config --global core.hooksPath", Output: foreignDir},
+			},
+			wantErr: fmt.Sprintf("core.hooksPath is set globally to '%s', use --force to replace it", foreignDir),
+		},
+		{
+			name: "foreign core.hooksPath with force",
+			args: InstallArgs{Global: true, Force: true},
+			git: []cmdtest.Out{
+				{Command: "git config --global core.hooksPath", Output: foreignDir},
+				{Command: "git config --global lefthook.previousHooksPath " + foreignDir},
+				{Command: "git config --global core.hooksPath " + hooksDir},
+			},
+			wantHooksDir: hooksDir,
+		},
+	} {
+		t.Run(fmt.Sprintf("%d: %s", n, tt.name), func(t *testing.T) {
+			assert := assert.New(t)
+
+			fs := afero.NewMemMapFs()
+			lefthook := &Lefthook{
+				logger: loggertest.New(),
+				fs:     fs,
+				repo:   gittest.NewRepositoryBuilder().Fs(fs).Cmd(cmdtest.NewOrdered(t, tt.git)).Build(),
+			}
+
+			err := lefthook.InstallGlobal(t.Context(), tt.args)
+			if len(tt.wantErr) > 0 {
+				assert.EqualError(err, tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+
+			for hook := range config.AvailableHooks {
+				assert.True(lefthook.isGlobalHook(filepath.Join(tt.wantHooksDir, hook)), hook)
+			}
+		})
+	}
+}
+
+func TestLefthookUninstallGlobal(t *testing.T) {
+	hooksDir, err := filepath.Abs("global-hooks")
+	assert.NoError(t, err)
+
+	foreignDir, err := filepath.Abs("custom-hooks")
+	assert.NoError(t, err)
+
+	for n, tt := range [...]struct {
+		name string
+		git  []cmdtest.Out
+	}{
+		{
+			name: "unset",
+			git: []cmdtest.Out{
+				{Command: "git config --global core.hooksPath", Output: hooksDir},
+				{Command: "git config --global lefthook.previousHooksPath"},
+				{Command: "git config --global --unset-all core.hooksPath"},
+				{Command: "git config --global init.templateDir"},
+			},
+		},
+		{
+			name: "restore replaced",
+			git: []cmdtest.Out{
+				{Command: "git config --global core.hooksPath", Output: hooksDir},
+				{Command: "git config --global lefthook.previousHooksPath", Output: foreignDir},
+				{Command: "git config --global core.hooksPath " + foreignDir},
+				{Command: "git config --global --unset-all lefthook.previousHooksPath"},
+				{Command: "git config --global init.templateDir"},
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("%d: %s", n, tt.name), func(t *testing.T) {
+			assert := assert.New(t)
+
+			fs := afero.NewMemMapFs()
+			assert.NoError(afero.WriteFile(fs, filepath.Join(hooksDir, "pre-commit"), templates.Hook("pre-commit", templates.Args{Global: true}), 0o755))
+			assert.NoError(afero.WriteFile(fs, filepath.Join(hooksDir, "custom"), []byte("#!/bin/sh\n"), 0o755))
+
+			lefthook := &Lefthook{
+				logger: loggertest.New(),
+				fs:     fs,
+				repo:   gittest.NewRepositoryBuilder().Fs(fs).Cmd(cmdtest.NewOrdered(t, tt.git)).Build(),
+			}
+
+			assert.NoError(lefthook.UninstallGlobal(t.Context()))
+
+			ok, err := afero.Exists(fs, filepath.Join(hooksDir, "pre-commit"))
+			assert.NoError(err)
+			assert.False(ok)
+
+			ok, err = afero.Exists(fs, filepath.Join(hooksDir, "custom"))
+			assert.NoError(err)
+			assert.True(ok)
+		})
+	}
+}
+
+func TestLefthookInstallWithGlobalHooks(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	globalDir, err := filepath.Abs("global-hooks")
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "lefthook.yml"), []byte("pre-commit:\n  jobs:\n    - run: yarn test\n"), 0o644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(globalDir, "pre-commit"), templates.Hook("pre-commit", templates.Args{Global: true}), 0o755))
+
+	lefthook := &Lefthook{
+		logger: loggertest.New(),
+		fs:     fs,
+		repo: gittest.NewRepositoryBuilder().Root(root).Fs(fs).Cmd(cmdtest.NewOrdered(t, []cmdtest.Out{
+			{Command: "git config --local core.hooksPath"},
+			{Command: "git config --global core.hooksPath", Output: globalDir},
+			{Command: "git rev-parse --path-format=absolute --git-common-dir", Output: gittest.GitPath(root)},
+		})).Build(),
+	}
+	lefthook.repo.HooksPath = globalDir
+
+	assert.NoError(t, lefthook.Install(t.Context(), InstallArgs{}, nil))
+
+	assert.True(t, lefthook.isGlobalHook(filepath.Join(globalDir, "pre-commit")))
+	assert.True(t, lefthook.isLefthookFile(filepath.Join(gittest.GitPath(root), "hooks", "pre-commit")))
+}
+
+func TestLefthookCheckInstallGlobal(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	globalDir, err := filepath.Abs("global-hooks")
+	assert.NoError(t, err)
+
+	for n, tt := range [...]struct {
+		name    string
+		hooks   []string
+		global  bool
+		wantErr error
+	}{
+		{
+			name:  "all hooks",
+			hooks: slices.Collect(maps.Keys(config.AvailableHooks)),
+		},
+		{
+			name:    "missing hook",
+			hooks:   []string{"pre-commit"},
+			wantErr: ErrNotInstalled,
+		},
+		{
+			name:   "all hooks with --global",
+			hooks:  slices.Collect(maps.Keys(config.AvailableHooks)),
+			global: true,
+		},
+		{
+			name:    "missing hook with --global",
+			hooks:   []string{"pre-commit", "commit-msg"},
+			global:  true,
+			wantErr: ErrNotInstalled,
+		},
+	} {
+		t.Run(fmt.Sprintf("%d: %s", n, tt.name), func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "lefthook.yml"), []byte("pre-commit:\n  jobs:\n    - run: yarn test\ncommit-msg:\n  jobs:\n    - run: yarn lint\n"), 0o644))
+			for _, hook := range tt.hooks {
+				assert.NoError(t, afero.WriteFile(fs, filepath.Join(globalDir, hook), templates.Hook(hook, templates.Args{Global: true}), 0o755))
+			}
+
+			lefthook := &Lefthook{
+				logger: loggertest.New(),
+				fs:     fs,
+				repo: gittest.NewRepositoryBuilder().Root(root).Fs(fs).Cmd(cmdtest.NewTracking(func(command, _root string, out io.Writer) error {
+					if command == "git config --global core.hooksPath" {
+						_, err := io.WriteString(out, globalDir)
+						return err
+					}
+
+					return nil
+				})).Build(),
+			}
+			lefthook.repo.HooksPath = globalDir
+
+			if tt.global {
+				err = lefthook.CheckGlobalInstall(t.Context())
+			} else {
+				err = lefthook.CheckInstall(t.Context())
+			}
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
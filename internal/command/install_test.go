@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -1066,3 +1067,82 @@ pre-commit:
 		})
 	}
 }
+
+func TestLefthookInstallChain(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	configPath := filepath.Join(root, "lefthook.yml")
+	hookPath := filepath.Join(gittest.GitPath(root), "hooks", "pre-commit")
+	original := "#!/bin/sh\necho original\n"
+
+	for n, tt := range [...]struct {
+		name, config, hook string
+		chain, wantChained bool
+	}{
+		{
+			name:   "without chain",
+			config: "pre-commit:\n  jobs:\n    - run: yarn test\n",
+			hook:   original,
+		},
+		{
+			name:        "with --chain",
+			config:      "pre-commit:\n  jobs:\n    - run: yarn test\n",
+			hook:        original,
+			chain:       true,
+			wantChained: true,
+		},
+		{
+			name:        "with chain_existing_hooks",
+			config:      "chain_existing_hooks: true\npre-commit:\n  jobs:\n    - run: yarn test\n",
+			hook:        original,
+			wantChained: true,
+		},
+		{
+			name:   "drops chain mode on reinstall without --chain",
+			config: "pre-commit:\n  jobs:\n    - run: yarn test\n",
+			hook:   "#!/bin/sh\n# LEFTHOOK\nif test -x \"$0.old\"\nthen\n  \"$0.old\" \"$@\"\nfi\n",
+		},
+	} {
+		t.Run(fmt.Sprintf("%d: %s", n, tt.name), func(t *testing.T) {
+			assert := assert.New(t)
+
+			fs := afero.NewMemMapFs()
+			lefthook := &Lefthook{
+				logger: loggertest.New(),
+				fs:     fs,
+				repo: gittest.NewRepositoryBuilder().
+					Root(root).
+					Fs(fs).
+					Cmd(cmdtest.NewOrdered(t, []cmdtest.Out{
+						{Command: "git config --local core.hooksPath"},
+						{Command: "git config --global core.hooksPath"},
+					})).
+					Build(),
+			}
+
+			assert.NoError(afero.WriteFile(fs, configPath, []byte(tt.config), 0o644))
+			assert.NoError(afero.WriteFile(fs, hookPath, []byte(tt.hook), 0o755))
+			if tt.hook != original {
+				assert.NoError(afero.WriteFile(fs, hookPath+".old", []byte(original), 0o755))
+			}
+
+			assert.NoError(lefthook.Install(t.Context(), InstallArgs{Chain: tt.chain}, nil))
+
+			assert.True(lefthook.isLefthookFile(hookPath))
+			content, err := afero.ReadFile(fs, hookPath)
+			assert.NoError(err)
+			assert.Equal(tt.wantChained, strings.Contains(string(content), `"$0.old"`))
+
+			old, err := afero.ReadFile(fs, hookPath+".old")
+			assert.NoError(err)
+			assert.Equal(original, string(old))
+
+			assert.NoError(lefthook.Uninstall(t.Context(), UninstallArgs{}))
+
+			restored, err := afero.ReadFile(fs, hookPath)
+			assert.NoError(err)
+			assert.Equal(original, string(restored))
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/evilmartians/lefthook/v2/internal/config"
 	"github.com/evilmartians/lefthook/v2/internal/git"
 	"github.com/evilmartians/lefthook/v2/internal/logger"
+	"github.com/evilmartians/lefthook/v2/internal/system"
 	"github.com/evilmartians/lefthook/v2/internal/templates"
 )
 
@@ -39,17 +40,7 @@ type Lefthook struct {
 
 // NewLefthook returns an instance of Lefthook.
 func NewLefthook(verbose bool, colors string) (*Lefthook, error) {
-	l := logger.New(os.Stdout)
-	switch colors {
-	case "on", "yes", "true", "1":
-		l.EnableColors()
-	case "off", "no", "false", "0":
-		l.DisableColors()
-	}
-
-	if verbose || isEnvEnabled(EnvVerbose) {
-		l.SetLevel(logger.LevelDebug)
-	}
+	l := newLogger(verbose, colors)
 
 	fs := afero.NewOsFs()
 	repo, err := git.NewRepo(fs, l)
@@ -64,6 +55,41 @@ func NewLefthook(verbose bool, colors string) (*Lefthook, error) {
 	}, nil
 }
 
+// NewGlobalLefthook returns an instance of Lefthook which doesn't require
+// a Git repository. It can only run the commands managing global hooks.
+func NewGlobalLefthook(verbose bool, colors string) *Lefthook {
+	l := newLogger(verbose, colors)
+
+	fs := afero.NewOsFs()
+	repo := &git.Repo{
+		Fs:  fs,
+		Git: git.NewCommander(system.Cmd, l),
+	}
+	repo.ResetCache()
+
+	return &Lefthook{
+		logger: l,
+		fs:     fs,
+		repo:   repo,
+	}
+}
+
+func newLogger(verbose bool, colors string) *logger.Logger {
+	l := logger.New(os.Stdout)
+	switch colors {
+	case "on", "yes", "true", "1":
+		l.EnableColors()
+	case "off", "no", "false", "0":
+		l.DisableColors()
+	}
+
+	if verbose || isEnvEnabled(EnvVerbose) {
+		l.SetLevel(logger.LevelDebug)
+	}
+
+	return l
+}
+
 func (l *Lefthook) LoadConfig() (*config.Config, error) {
 	loader := config.NewLoader(l.repo, l.logger)
 	return loader.Load()
@@ -72,7 +98,8 @@ func (l *Lefthook) LoadConfig() (*config.Config, error) {
 func (l *Lefthook) reloadConfig(cfg *config.Config) (*config.Config, error) {
 	l.logger.Debug("Reloading config...")
 
-	loader := config.NewLoader(l.repo, l.logger)
+	// Remotes are verified against lefthook.lock by the caller
+	loader := config.NewLoader(l.repo, l.logger).SkipLock()
 
 	buffer := new(bytes.Buffer)
 	if err := cfg.Dump(config.JSONCompactFormat, buffer); err != nil {
@@ -0,0 +1,150 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/migrate"
+)
+
+var (
+	errConfigExists    = errors.New("lefthook config already exists, use --force to overwrite it")
+	errNoMigrateSource = errors.New("no husky, pre-commit, or overcommit config found, use --from option")
+	errNoHooks         = errors.New("no hooks were translated")
+
+	// Strings identifying the hooks installed by the other tools.
+	migratedHookFingerprints = map[string]string{
+		migrate.PreCommit:  "# File generated by pre-commit",
+		migrate.Overcommit: "# Entrypoint for Overcommit hook integration",
+	}
+)
+
+// migratedHookBackup is the suffix of the hooks of the migrated tool moved
+// aside by cleanup.
+const migratedHookBackup = ".bak"
+
+type MigrateArgs struct {
+	From, Format string
+
+	Force, Cleanup bool
+}
+
+// Migrate translates the config of another Git hooks manager into lefthook config.
+func (l *Lefthook) Migrate(_ctx context.Context, args MigrateArgs) error {
+	root := l.repo.RootPath
+
+	existing, _ := l.findMainConfig(root)
+	if len(existing) > 0 && !args.Force {
+		return errConfigExists
+	}
+
+	from := args.From
+	if len(from) == 0 {
+		var ok bool
+		if from, ok = migrate.Detect(l.fs, root); !ok {
+			return errNoMigrateSource
+		}
+		l.logger.Infof("Found %s config: %s", from, migrate.Paths[from])
+	}
+
+	res, err := migrate.Migrate(l.fs, root, from)
+	if err != nil {
+		return fmt.Errorf("couldn't read %s config: %w", from, err)
+	}
+
+	if len(res.Untranslated) > 0 {
+		l.logger.Warnf(
+			"Couldn't translate, configure manually:\n  %s",
+			strings.Join(res.Untranslated, "\n  "),
+		)
+	}
+
+	if len(res.Hooks) == 0 {
+		return errNoHooks
+	}
+
+	var buf bytes.Buffer
+	cfg := &config.Config{Hooks: res.Hooks}
+	if err = cfg.Dump(dumpFormat(args.Format), &buf); err != nil {
+		return fmt.Errorf("couldn't dump config: %w", err)
+	}
+
+	if len(existing) > 0 {
+		if err = l.fs.Remove(existing); err != nil {
+			return err
+		}
+	}
+
+	path := filepath.Join(root, "lefthook"+configExtension(args.Format))
+	if err = afero.WriteFile(l.fs, path, buf.Bytes(), configFileMode); err != nil {
+		return err
+	}
+	l.logger.Infof("Added config: %s", path)
+
+	if args.Cleanup {
+		if err = l.cleanupMigrated(from); err != nil {
+			return err
+		}
+	}
+
+	l.logger.Info("Review the config and run `lefthook install` to install the hooks")
+
+	return nil
+}
+
+func configExtension(format string) string {
+	switch format {
+	case "json":
+		return ".json"
+	case "toml":
+		return ".toml"
+	default:
+		return ".yml"
+	}
+}
+
+// cleanupMigrated moves aside the hooks installed by the migrated tool, so they
+// don't get preserved as .old hooks on lefthook install.
+func (l *Lefthook) cleanupMigrated(from string) error {
+	if from == migrate.Husky {
+		local, _ := l.getHooksPathConfig()
+		if !strings.Contains(local, ".husky") {
+			return nil
+		}
+
+		return l.unsetHooksPathConfig(local, "")
+	}
+
+	fingerprint := migratedHookFingerprints[from]
+
+	hooks, err := afero.ReadDir(l.fs, l.repo.HooksPath)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range hooks {
+		hookFile := filepath.Join(l.repo.HooksPath, file.Name())
+		if file.IsDir() {
+			continue
+		}
+
+		content, err := afero.ReadFile(l.fs, hookFile)
+		if err != nil || !bytes.Contains(content, []byte(fingerprint)) {
+			continue
+		}
+
+		if err := l.fs.Rename(hookFile, hookFile+migratedHookBackup); err != nil {
+			return err
+		}
+		l.logger.Infof("Moved hook: %s -> %s", hookFile, hookFile+migratedHookBackup)
+	}
+
+	return nil
+}
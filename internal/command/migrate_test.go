@@ -0,0 +1,140 @@
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+func TestLefthookMigrate(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	hookPath := func(hook string) string {
+		return filepath.Join(gittest.GitPath(root), "hooks", hook)
+	}
+
+	preCommitConfig := `
+repos:
+  - repo: local
+    hooks:
+      - id: lint
+        entry: make lint
+        language: system
+        pass_filenames: false
+`
+
+	overcommitConfig := `
+PreCommit:
+  RuboCop:
+    enabled: true
+    command: ['bundle', 'exec', 'rubocop']
+`
+
+	for name, tt := range map[string]struct {
+		args                    MigrateArgs
+		existing                map[string]string
+		err                     error
+		wantConfigs             map[string]string
+		wantExist, wantNotExist []string
+	}{
+		"yaml": {
+			args: MigrateArgs{From: "pre-commit"},
+			existing: map[string]string{
+				".pre-commit-config.yaml": preCommitConfig,
+			},
+			wantConfigs: map[string]string{
+				"lefthook.yml": "pre-commit:\n  jobs:\n    - name: lint\n      run: make lint\n",
+			},
+		},
+		"detected source in toml": {
+			args: MigrateArgs{Format: "toml"},
+			existing: map[string]string{
+				".pre-commit-config.yaml": preCommitConfig,
+			},
+			wantConfigs: map[string]string{
+				"lefthook.toml": "[pre-commit]\n[[pre-commit.jobs]]\nname = 'lint'\nrun = 'make lint'\n",
+			},
+		},
+		"nothing to migrate": {
+			err: errNoMigrateSource,
+		},
+		"config exists": {
+			args: MigrateArgs{From: "pre-commit"},
+			existing: map[string]string{
+				".pre-commit-config.yaml": preCommitConfig,
+				".lefthook.yml":           "# old",
+			},
+			err: errConfigExists,
+			wantConfigs: map[string]string{
+				".lefthook.yml": "# old",
+			},
+		},
+		"overwrite with cleanup": {
+			args: MigrateArgs{From: "pre-commit", Force: true, Cleanup: true},
+			existing: map[string]string{
+				".pre-commit-config.yaml": preCommitConfig,
+				".lefthook.yml":           "# old",
+				hookPath("pre-commit"):    "#!/usr/bin/env bash\n# File generated by pre-commit: https://pre-commit.com\n",
+				hookPath("post-commit"):   "#!/bin/sh\necho custom\n",
+			},
+			wantConfigs: map[string]string{
+				"lefthook.yml": "pre-commit:\n  jobs:\n    - name: lint\n      run: make lint\n",
+			},
+			wantExist:    []string{hookPath("post-commit"), hookPath("pre-commit") + migratedHookBackup},
+			wantNotExist: []string{filepath.Join(root, ".lefthook.yml"), hookPath("pre-commit")},
+		},
+		"overcommit cleanup": {
+			args: MigrateArgs{From: "overcommit", Cleanup: true},
+			existing: map[string]string{
+				".overcommit.yml":      overcommitConfig,
+				hookPath("pre-commit"): "#!/usr/bin/env ruby\n\n# Entrypoint for Overcommit hook integration. Installing Overcommit will result\n",
+				hookPath("pre-push"):   "#!/bin/sh\n# run overcommit checks manually\n",
+			},
+			wantExist:    []string{hookPath("pre-push"), hookPath("pre-commit") + migratedHookBackup},
+			wantNotExist: []string{hookPath("pre-commit")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			lefthook := &Lefthook{
+				logger: loggertest.New(),
+				fs:     fs,
+				repo:   gittest.NewRepositoryBuilder().Fs(fs).Root(root).Build(),
+			}
+
+			for path, content := range tt.existing {
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(root, path)
+				}
+				assert.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o755))
+			}
+
+			err := lefthook.Migrate(t.Context(), tt.args)
+			assert.Equal(t, tt.err, err)
+
+			for path, content := range tt.wantConfigs {
+				data, err := afero.ReadFile(fs, filepath.Join(root, path))
+				assert.NoError(t, err)
+				assert.Equal(t, content, string(data))
+			}
+
+			for _, path := range tt.wantExist {
+				ok, err := afero.Exists(fs, path)
+				assert.NoError(t, err)
+				assert.True(t, ok, path)
+			}
+
+			for _, path := range tt.wantNotExist {
+				ok, err := afero.Exists(fs, path)
+				assert.NoError(t, err)
+				assert.False(t, ok, path)
+			}
+		})
+	}
+}
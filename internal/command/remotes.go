@@ -0,0 +1,512 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/git"
+	"github.com/evilmartians/lefthook/v2/internal/logger"
+)
+
+const (
+	shortCommitLength = 12
+	fetchTimeLayout   = "2006-01-02 15:04:05"
+	remoteBackup      = ".orig"
+)
+
+var errNoRemotes = errors.New("no remotes configured")
+
+type RemotesArgs struct {
+	// Names of the remotes. All remotes are used if empty.
+	Names []string
+}
+
+// RemotesList prints the configured remotes with their fetch state.
+func (l *Lefthook) RemotesList(_ctx context.Context) error {
+	remotes, err := l.configuredRemotes()
+	if err != nil {
+		return err
+	}
+
+	lock, err := config.ReadLock(l.fs, l.repo.RootPath)
+	if err != nil {
+		return err
+	}
+
+	folders := make(map[string]struct{})
+	for _, remote := range remotes {
+		folder := remote.Folder(l.repo)
+		folders[folder] = struct{}{}
+
+		title := remote.Source()
+		if len(remote.Ref) > 0 {
+			title += " " + l.logger.Paint(logger.ColorGray, "("+remote.Ref+")")
+		}
+		l.logger.Info(l.logger.Paint(logger.ColorCyan, title))
+		l.logger.Info("  folder:  " + l.relPath(folder))
+
+		if !remote.Fetched() {
+			l.logger.Info("  used in place")
+			continue
+		}
+
+		if ok, _ := afero.DirExists(l.fs, folder); !ok {
+			l.logger.Info("  " + l.logger.Paint(logger.ColorYellow, "not fetched"))
+			continue
+		}
+
+		l.logger.Info("  commit:  " + l.remoteCommitState(remote, lock))
+
+		fetched := "unknown"
+		if fetchTime, err := l.remoteFetchTime(remote); err == nil {
+			fetched = fetchTime.Format(fetchTimeLayout)
+		} else if info, err := l.fs.Stat(folder); err == nil {
+			fetched = info.ModTime().Format(fetchTimeLayout)
+		}
+		if l.shouldRefetch(remote) {
+			fetched += " " + l.logger.Paint(logger.ColorYellow, "(refetch due)")
+		}
+		l.logger.Info("  fetched: " + fetched)
+	}
+
+	stale, err := l.staleRemoteFolders(folders)
+	if err != nil {
+		return err
+	}
+	for _, folder := range stale {
+		l.logger.Info(l.logger.Paint(logger.ColorGray, "stale: "+l.relPath(folder)))
+	}
+
+	return nil
+}
+
+// RemotesUpdate fetches the latest version of the remotes ignoring the commits
+// pinned in lefthook.lock, and pins the new ones.
+func (l *Lefthook) RemotesUpdate(_ctx context.Context, args RemotesArgs) error {
+	remotes, err := l.selectRemotes(args.Names)
+	if err != nil {
+		return err
+	}
+
+	lock, err := config.ReadLock(l.fs, l.repo.RootPath)
+	if err != nil {
+		return err
+	}
+
+	// Locked version ranges would resolve to the locked tags, unpin the remotes
+	// until they are fetched
+	previous := make(map[*config.Remote]string)
+	if lock != nil {
+		unpinned := &config.Lock{Remotes: lock.Remotes}
+		for _, remote := range remotes {
+			if locked := lock.Find(remote); locked != nil {
+				previous[remote] = locked.Commit
+				unpinned.Remotes = removeLocked(unpinned.Remotes, locked)
+			}
+		}
+
+		if err = unpinned.Write(l.fs, l.repo.RootPath); err != nil {
+			return err
+		}
+	}
+
+	if err = l.fetchRemoteUpdates(remotes); err != nil {
+		// Keep the pins if the remotes couldn't be updated
+		if lock != nil {
+			err = errors.Join(err, lock.Write(l.fs, l.repo.RootPath))
+		}
+
+		return err
+	}
+
+	lock, err = config.ReadLock(l.fs, l.repo.RootPath)
+	if err != nil {
+		return err
+	}
+
+	for _, remote := range remotes {
+		var current string
+		if locked := lock.Find(remote); locked != nil {
+			current = locked.Commit
+		}
+
+		switch old := previous[remote]; {
+		case len(old) == 0:
+			l.logger.Infof("Updated %s: %s", remote.Source(), shortCommit(current))
+		case old == current:
+			l.logger.Infof("%s is up to date: %s", remote.Source(), shortCommit(current))
+		default:
+			l.logger.Infof("Updated %s: %s → %s", remote.Source(), shortCommit(old), shortCommit(current))
+		}
+	}
+
+	return nil
+}
+
+// fetchRemoteUpdates fetches the latest version of the remotes and pins them
+// in lefthook.lock.
+func (l *Lefthook) fetchRemoteUpdates(remotes []*config.Remote) error {
+	for _, remote := range remotes {
+		if err := l.syncRemote(remote, nil, true); err != nil {
+			return fmt.Errorf("couldn't update %s: %w", remote.Source(), err)
+		}
+	}
+
+	cfg, err := l.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err = l.updateLock(cfg); err != nil {
+		return fmt.Errorf("failed to update %s: %w", config.LockFileName, err)
+	}
+
+	return nil
+}
+
+// RemotesPrune removes the fetched remotes which are not configured anymore.
+func (l *Lefthook) RemotesPrune(_ctx context.Context) error {
+	remotes, err := l.configuredRemotes()
+	if err != nil && !errors.Is(err, errNoRemotes) {
+		return err
+	}
+
+	folders := make(map[string]struct{})
+	for _, remote := range remotes {
+		folders[remote.Folder(l.repo)] = struct{}{}
+	}
+
+	pruned, err := l.pruneRemotes(folders)
+	if err != nil {
+		return err
+	}
+
+	if len(pruned) == 0 {
+		l.logger.Info("Nothing to prune")
+		return nil
+	}
+
+	for _, folder := range pruned {
+		l.logger.Info("Removed " + l.relPath(folder))
+	}
+
+	return nil
+}
+
+// RemotesDiff prints the difference between the current merged config and
+// the config merged with the latest version of the remotes. The fetched
+// remotes are kept intact.
+func (l *Lefthook) RemotesDiff(_ctx context.Context, args RemotesArgs) error {
+	remotes, err := l.selectRemotes(args.Names)
+	if err != nil {
+		return err
+	}
+
+	before, err := l.dumpUnlocked()
+	if err != nil {
+		return err
+	}
+
+	restore, err := l.backupRemotes(remotes)
+	defer func() {
+		if rerr := restore(); rerr != nil {
+			l.logger.Errorf("couldn't restore the remotes: %s", rerr)
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, remote := range remotes {
+		if err = l.syncRemote(remote, nil, true); err != nil {
+			return fmt.Errorf("couldn't fetch %s: %w", remote.Source(), err)
+		}
+	}
+
+	after, err := l.dumpUnlocked()
+	if err != nil {
+		return err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(before),
+		B:        difflib.SplitLines(after),
+		FromFile: "current",
+		ToFile:   "updated",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(diff) == 0 {
+		l.logger.Info("No changes")
+		return nil
+	}
+
+	l.logger.Info(strings.TrimSuffix(diff, "\n"))
+
+	return nil
+}
+
+// configuredRemotes returns the remotes from the main config. The remotes are
+// not loaded, so the command works even if they don't match lefthook.lock.
+func (l *Lefthook) configuredRemotes() ([]*config.Remote, error) {
+	all, err := config.NewLoader(l.repo, l.logger).LoadRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make([]*config.Remote, 0, len(all))
+	for _, remote := range all {
+		if remote.Configured() {
+			remotes = append(remotes, remote)
+		}
+	}
+
+	if len(remotes) == 0 {
+		return nil, errNoRemotes
+	}
+
+	return remotes, nil
+}
+
+// selectRemotes returns the fetched remotes matching the names. A remote
+// matches its URL or the name of its folder.
+func (l *Lefthook) selectRemotes(names []string) ([]*config.Remote, error) {
+	remotes, err := l.configuredRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]*config.Remote, 0, len(remotes))
+	for _, remote := range remotes {
+		if remote.Fetched() && (len(names) == 0 || l.remoteMatches(remote, names)) {
+			selected = append(selected, remote)
+		}
+	}
+
+	for _, name := range names {
+		var found bool
+		for _, remote := range remotes {
+			if l.remoteMatches(remote, []string{name}) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("remote not found: %s", name)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no remotes to fetch, path remotes are used in place")
+	}
+
+	return selected, nil
+}
+
+func (l *Lefthook) remoteMatches(remote *config.Remote, names []string) bool {
+	folder := filepath.Base(remote.Folder(l.repo))
+	for _, name := range names {
+		if name == remote.Source() || name == folder || (len(remote.GitURL) > 0 && name == git.RemoteDirectoryName(remote.GitURL, "")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *Lefthook) remoteCommitState(remote *config.Remote, lock *config.Lock) string {
+	folder := remote.Folder(l.repo)
+
+	var commit string
+	var err error
+	if len(remote.ArchiveURL) > 0 {
+		commit, err = l.repo.ArchiveChecksum(folder)
+	} else {
+		commit, err = l.repo.RemoteCommit(folder)
+	}
+	if err != nil {
+		return l.logger.Paint(logger.ColorRed, err.Error())
+	}
+
+	state := shortCommit(commit)
+	switch locked := lock.Find(remote); {
+	case locked == nil:
+		state += " " + l.logger.Paint(logger.ColorGray, "(not locked)")
+	case locked.Commit == commit:
+		state += " " + l.logger.Paint(logger.ColorGreen, "(locked)")
+	default:
+		state += " " + l.logger.Paint(logger.ColorRed, "(locked at "+shortCommit(locked.Commit)+")")
+	}
+
+	return state
+}
+
+// pruneRemotes removes the folders of remotes folder except the given ones.
+func (l *Lefthook) pruneRemotes(keep map[string]struct{}) ([]string, error) {
+	stale, err := l.staleRemoteFolders(keep)
+	if err != nil {
+		return nil, err
+	}
+
+	pruned := make([]string, 0, len(stale))
+	for _, remotePath := range stale {
+		l.logger.Debug("Removing stale remote: ", remotePath)
+
+		if err := l.fs.RemoveAll(remotePath); err != nil {
+			l.logger.Error("failed to drop stale remote path: ", remotePath)
+			continue
+		}
+		pruned = append(pruned, remotePath)
+	}
+
+	return pruned, nil
+}
+
+func (l *Lefthook) staleRemoteFolders(keep map[string]struct{}) ([]string, error) {
+	entries, err := afero.ReadDir(l.fs, l.repo.RemotesFolder())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		remotePath := filepath.Join(l.repo.RemotesFolder(), entry.Name())
+		if _, ok := keep[remotePath]; !ok {
+			stale = append(stale, remotePath)
+		}
+	}
+
+	return stale, nil
+}
+
+// backupRemotes moves the fetched remotes aside and unpins them in
+// lefthook.lock. The returned function removes the remotes fetched after the
+// backup and restores the original ones with the lock.
+func (l *Lefthook) backupRemotes(remotes []*config.Remote) (func() error, error) {
+	var moved []string
+	var existing map[string]struct{}
+	var lock *config.Lock
+	restore := func() error {
+		var errs []error
+		if lock != nil {
+			errs = append(errs, lock.Write(l.fs, l.repo.RootPath))
+		}
+
+		if existing != nil {
+			fetched, err := l.staleRemoteFolders(existing)
+			errs = append(errs, err)
+			for _, folder := range fetched {
+				errs = append(errs, l.fs.RemoveAll(folder))
+			}
+		}
+
+		for _, folder := range moved {
+			errs = append(errs, l.fs.RemoveAll(folder), l.fs.Rename(folder+remoteBackup, folder))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	for _, remote := range remotes {
+		folder := remote.Folder(l.repo)
+		if err := l.fs.RemoveAll(folder + remoteBackup); err != nil {
+			return restore, err
+		}
+
+		err := l.fs.Rename(folder, folder+remoteBackup)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return restore, err
+		}
+		moved = append(moved, folder)
+	}
+
+	// Locked version ranges would resolve to the locked tags
+	current, err := config.ReadLock(l.fs, l.repo.RootPath)
+	if err != nil {
+		return restore, err
+	}
+	if current != nil {
+		unpinned := &config.Lock{Remotes: current.Remotes}
+		for _, remote := range remotes {
+			if locked := current.Find(remote); locked != nil {
+				unpinned.Remotes = removeLocked(unpinned.Remotes, locked)
+			}
+		}
+
+		lock = current
+		if err = unpinned.Write(l.fs, l.repo.RootPath); err != nil {
+			return restore, err
+		}
+	}
+
+	entries, err := afero.ReadDir(l.fs, l.repo.RemotesFolder())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return restore, err
+	}
+
+	existing = make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		existing[filepath.Join(l.repo.RemotesFolder(), entry.Name())] = struct{}{}
+	}
+
+	return restore, nil
+}
+
+func (l *Lefthook) dumpUnlocked() (string, error) {
+	cfg, err := config.NewLoader(l.repo, l.logger).SkipLock().Load()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err = cfg.Dump(config.YAMLFormat, &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (l *Lefthook) relPath(path string) string {
+	if rel, err := filepath.Rel(l.repo.RootPath, path); err == nil {
+		return rel
+	}
+
+	return path
+}
+
+func removeLocked(remotes []*config.LockedRemote, locked *config.LockedRemote) []*config.LockedRemote {
+	result := make([]*config.LockedRemote, 0, len(remotes))
+	for _, remote := range remotes {
+		if remote != locked {
+			result = append(result, remote)
+		}
+	}
+
+	return result
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > shortCommitLength {
+		return commit[:shortCommitLength]
+	}
+
+	return commit
+}
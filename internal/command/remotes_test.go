@@ -0,0 +1,152 @@
+package command
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/git"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+const remotesConfig = `
+remotes:
+  - git_url: https://github.com/evilmartians/lefthook
+    ref: v1.4.0
+  - archive_url: https://example.com/configs.tar.gz
+  - path: shared
+`
+
+func TestLefthookSelectRemotes(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		names   []string
+		sources []string
+		wantErr string
+	}{
+		"all": {
+			sources: []string{"https://github.com/evilmartians/lefthook", "https://example.com/configs.tar.gz"},
+		},
+		"by folder name": {
+			names:   []string{"lefthook-v1.4.0"},
+			sources: []string{"https://github.com/evilmartians/lefthook"},
+		},
+		"by repository name": {
+			names:   []string{"lefthook"},
+			sources: []string{"https://github.com/evilmartians/lefthook"},
+		},
+		"by url": {
+			names:   []string{"https://example.com/configs.tar.gz"},
+			sources: []string{"https://example.com/configs.tar.gz"},
+		},
+		"path remote": {
+			names:   []string{"shared"},
+			wantErr: "no remotes to fetch, path remotes are used in place",
+		},
+		"unknown": {
+			names:   []string{"unknown"},
+			wantErr: "remote not found: unknown",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "lefthook.yml"), []byte(remotesConfig), 0o644))
+
+			lefthook := &Lefthook{
+				logger: loggertest.New(),
+				fs:     fs,
+				repo:   gittest.NewRepositoryBuilder().Root(root).Fs(fs).Build(),
+			}
+
+			remotes, err := lefthook.selectRemotes(tt.names)
+			if len(tt.wantErr) > 0 {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			sources := make([]string, 0, len(remotes))
+			for _, remote := range remotes {
+				sources = append(sources, remote.Source())
+			}
+			assert.Equal(t, tt.sources, sources)
+		})
+	}
+}
+
+func TestLefthookRemotesPrune(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "lefthook.yml"), []byte(remotesConfig), 0o644))
+
+	lefthook := &Lefthook{
+		logger: loggertest.New(),
+		fs:     fs,
+		repo:   gittest.NewRepositoryBuilder().Root(root).Fs(fs).Build(),
+	}
+
+	remotesFolder := lefthook.repo.RemotesFolder()
+	archiveFolder := git.ArchiveDirectoryName("https://example.com/configs.tar.gz")
+	for _, folder := range []string{"lefthook-v1.4.0", "lefthook-v1.3.0", archiveFolder, archiveFolder + ".tmp", "configs-archive"} {
+		assert.NoError(t, fs.MkdirAll(filepath.Join(remotesFolder, folder), 0o755))
+	}
+
+	assert.NoError(t, lefthook.RemotesPrune(t.Context()))
+
+	for folder, exists := range map[string]bool{
+		"lefthook-v1.4.0":      true,
+		archiveFolder:          true,
+		"lefthook-v1.3.0":      false,
+		archiveFolder + ".tmp": false,
+		"configs-archive":      false,
+	} {
+		ok, err := afero.DirExists(fs, filepath.Join(remotesFolder, folder))
+		assert.NoError(t, err)
+		assert.Equal(t, exists, ok, folder)
+	}
+}
+
+func TestLefthookRemotesUpdateKeepsLock(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "lefthook.yml"), []byte(remotesConfig), 0o644))
+
+	lock := []byte(`# Generated by lefthook install. Commit this file to pin the remotes.
+remotes:
+  - git_url: https://github.com/evilmartians/lefthook
+    ref: v1.4.0
+    commit: 0123456789abcdef0123456789abcdef01234567
+    hash: sha256:0123
+`)
+	lockPath := filepath.Join(root, config.LockFileName)
+	assert.NoError(t, afero.WriteFile(fs, lockPath, lock, 0o644))
+
+	cmd := cmdtest.NewTracking(func(_command string, _root string, _out io.Writer) error {
+		return errors.New("network is unreachable")
+	})
+	lefthook := &Lefthook{
+		logger: loggertest.New(),
+		fs:     fs,
+		repo:   gittest.NewRepositoryBuilder().Root(root).Fs(fs).Cmd(cmd).Build(),
+	}
+
+	err = lefthook.RemotesUpdate(t.Context(), RemotesArgs{Names: []string{"lefthook"}})
+	assert.Error(t, err)
+
+	data, err := afero.ReadFile(fs, lockPath)
+	assert.NoError(t, err)
+	assert.Equal(t, string(lock), string(data))
+}
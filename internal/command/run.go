@@ -1,6 +1,7 @@
 package command
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -8,19 +9,25 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/evilmartians/lefthook/v2/internal/config"
 	"github.com/evilmartians/lefthook/v2/internal/git"
 	"github.com/evilmartians/lefthook/v2/internal/logger"
 	"github.com/evilmartians/lefthook/v2/internal/run"
+	"github.com/evilmartians/lefthook/v2/internal/run/report"
 	"github.com/evilmartians/lefthook/v2/internal/run/result"
+	"github.com/evilmartians/lefthook/v2/internal/run/trace"
 	"github.com/evilmartians/lefthook/v2/internal/version"
 )
 
 const (
-	envEnabled = "LEFTHOOK"        // "0", "false"
-	envOutput  = "LEFTHOOK_OUTPUT" // "meta,success,failure,summary,skips,execution,execution_out,execution_info"
+	envEnabled     = "LEFTHOOK"              // "0", "false"
+	envOutput      = "LEFTHOOK_OUTPUT"       // "meta,success,failure,summary,skips,execution,execution_out,execution_info"
+	envMaxParallel = "LEFTHOOK_MAX_PARALLEL" // "4"
+	envBaseRef     = "LEFTHOOK_BASE_REF"     // "origin/main"
 )
 
 var errPipedAndParallelSet = errors.New("conflicting options 'piped' and 'parallel' are set to 'true', remove one of this option from hook group")
@@ -30,15 +37,25 @@ type RunArgs struct {
 	AllFiles          bool
 	FilesFromStdin    bool
 	Force             bool
+	OnlyMatchingFiles bool
 	NoAutoInstall     bool
 	NoStageFixed      bool
+	NoCache           bool
 	SkipLFS           bool
 	Verbose           bool
+	FailFast          bool
+	MaxParallel       int
 	FailOnChanges     *bool
 	FailOnChangesDiff *bool
 	Hook              string
+	Report            string
+	JUnit             string
+	Trace             string
+	FromRef           string
+	ToRef             string
 	Exclude           []string
 	Files             []string
+	DeletedFiles      []string
 	RunOnlyCommands   []string
 	RunOnlyJobs       []string
 	RunOnlyTags       []string
@@ -57,8 +74,8 @@ func (l *Lefthook) Run(ctx context.Context, args RunArgs) error {
 		l.logger.SetLevel(logger.LevelDebug)
 	}
 
-	// Load config
-	cfg, err := l.LoadConfig()
+	// Load config, remotes are verified against lefthook.lock after syncing
+	cfg, err := config.NewLoader(l.repo, l.logger).SkipLock().Load()
 	if err != nil {
 		var errNotFound config.ConfigNotFoundError
 		if ok := errors.As(err, &errNotFound); ok {
@@ -110,12 +127,18 @@ func (l *Lefthook) Run(ctx context.Context, args RunArgs) error {
 		return nil
 	}
 
+	if err = config.NewLoader(l.repo, l.logger).VerifyLock(cfg); err != nil {
+		return err
+	}
+
 	files, err := getFiles(l.repo, args)
 	if err != nil {
 		return err
 	}
 	args.Files = files
 
+	l.repo.SetChangedFilesRange(cmp.Or(args.FromRef, os.Getenv(envBaseRef)), args.ToRef)
+
 	sourceDirs := getSourceDirs(l.repo, cfg)
 
 	failOnChanges, err := shouldFailOnChanges(args.FailOnChanges, hook.FailOnChanges)
@@ -124,6 +147,11 @@ func (l *Lefthook) Run(ctx context.Context, args RunArgs) error {
 	}
 	failOnChangesDiff := shouldFailOnChangesDiff(args.FailOnChangesDiff, hook.FailOnChangesDiff)
 
+	maxParallel, err := getMaxParallel(args.MaxParallel)
+	if err != nil {
+		return err
+	}
+
 	// Convert Commands and Scripts into Jobs
 	hook.Jobs = append(hook.Jobs, config.CommandsToJobs(hook.Commands)...)
 	hook.Commands = nil
@@ -131,7 +159,9 @@ func (l *Lefthook) Run(ctx context.Context, args RunArgs) error {
 	hook.Scripts = nil
 	args.RunOnlyJobs = append(args.RunOnlyJobs, args.RunOnlyCommands...)
 
-	return l.runHook(ctx, hook, l.repo, exLogger, run.Options{
+	reports := getReports(args, cfg.Report, hook.Name)
+
+	return l.runHook(ctx, hook, l.repo, exLogger, reports, run.Options{
 		DisableTTY:        cfg.NoTTY || args.NoTTY,
 		SkipLFS:           cfg.SkipLFS || args.SkipLFS,
 		Templates:         cfg.Templates,
@@ -139,8 +169,13 @@ func (l *Lefthook) Run(ctx context.Context, args RunArgs) error {
 		GitArgs:           args.GitArgs,
 		ExcludeFiles:      args.Exclude,
 		Files:             args.Files,
+		DeletedFiles:      args.DeletedFiles,
 		Force:             args.Force,
+		OnlyMatchingFiles: args.OnlyMatchingFiles,
 		NoStageFixed:      args.NoStageFixed,
+		NoCache:           args.NoCache,
+		FailFast:          args.FailFast,
+		MaxParallel:       maxParallel,
 		RunOnlyJobs:       args.RunOnlyJobs,
 		RunOnlyTags:       args.RunOnlyTags,
 		SourceDirs:        sourceDirs,
@@ -200,7 +235,7 @@ func getSourceDirs(repo *git.Repo, cfg *config.Config) []string {
 			sourceDirs = append(
 				sourceDirs,
 				filepath.Join(
-					repo.RemoteFolder(remote.GitURL, remote.Ref),
+					remote.Folder(repo),
 					cfg.SourceDir,
 				),
 			)
@@ -231,6 +266,30 @@ func shouldFailOnChanges(fromArg *bool, fromHook string) (bool, error) {
 	}
 }
 
+// getMaxParallel returns the limit of simultaneously running jobs set via
+// the argument or the ENV variable. Zero means the limit is not overridden.
+func getMaxParallel(fromArg int) (int, error) {
+	if fromArg != 0 {
+		if fromArg < 0 {
+			return 0, fmt.Errorf("invalid value for --jobs: %d", fromArg)
+		}
+
+		return fromArg, nil
+	}
+
+	fromEnv := os.Getenv(envMaxParallel)
+	if len(fromEnv) == 0 {
+		return 0, nil
+	}
+
+	maxParallel, err := strconv.Atoi(fromEnv)
+	if err != nil || maxParallel < 1 {
+		return 0, fmt.Errorf("invalid value for %s: %s", envMaxParallel, fromEnv)
+	}
+
+	return maxParallel, nil
+}
+
 func shouldFailOnChangesDiff(fromArg *bool, fromHook *bool) bool {
 	if fromArg != nil {
 		return *fromArg
@@ -248,13 +307,23 @@ func (l *Lefthook) runHook(
 	hook *config.Hook,
 	repo *git.Repo,
 	exLogger *logger.ExecutionLogger,
+	reports reportPaths,
 	opts run.Options,
 ) error {
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
 	defer stop()
 
+	if len(reports.trace) > 0 {
+		opts.Tracer = trace.New()
+	}
+
 	startTime := time.Now()
 	results, err := run.Run(ctx, hook, repo, exLogger, opts)
+	duration := time.Since(startTime)
+
+	// Reports are needed the most for the failed and interrupted runs
+	l.writeReports(reports, opts.Tracer, hook.Name, startTime, duration, results)
+
 	if err != nil {
 		var failOnChangesErr *run.FailOnChangesError
 		if errors.As(err, &failOnChangesErr) {
@@ -267,7 +336,7 @@ func (l *Lefthook) runHook(
 		return errors.New("Interrupted")
 	}
 
-	l.logSummary(exLogger, time.Since(startTime), results)
+	l.logSummary(exLogger, duration, results)
 
 	for _, result := range results {
 		if result.Failure() {
@@ -278,6 +347,77 @@ func (l *Lefthook) runHook(
 	return nil
 }
 
+// writeReports writes the trace, JSON, and JUnit reports if they are requested.
+func (l *Lefthook) writeReports(reports reportPaths, tracer *trace.Tracer, hookName string, startTime time.Time, duration time.Duration, results []result.Result) {
+	if tracer != nil {
+		if err := l.writeReport(reports.trace, tracer.Write); err != nil {
+			l.logger.Warnf("Couldn't write the trace: %s\n", err)
+		}
+	}
+
+	if len(reports.json) > 0 {
+		if err := l.writeReport(reports.json, func(w io.Writer) error {
+			return report.JSON(w, hookName, duration, results)
+		}); err != nil {
+			l.logger.Warnf("Couldn't write the JSON report: %s\n", err)
+		}
+	}
+
+	if len(reports.junit) > 0 {
+		if err := l.writeReport(reports.junit, func(w io.Writer) error {
+			return report.JUnit(w, hookName, startTime, duration, results)
+		}); err != nil {
+			l.logger.Warnf("Couldn't write the JUnit report: %s\n", err)
+		}
+	}
+}
+
+// reportPaths contains the paths of the report files, empty path means
+// the report is not written.
+type reportPaths struct {
+	json  string
+	junit string
+	trace string
+}
+
+// getReports resolves the paths of the reports. Arguments take precedence over the config.
+func getReports(args RunArgs, cfg *config.Report, hookName string) reportPaths {
+	reports := reportPaths{
+		json:  args.Report,
+		junit: args.JUnit,
+		trace: args.Trace,
+	}
+
+	if cfg != nil {
+		if len(reports.json) == 0 {
+			reports.json = strings.ReplaceAll(cfg.JSON, "{hook}", hookName)
+		}
+		if len(reports.junit) == 0 {
+			reports.junit = strings.ReplaceAll(cfg.JUnit, "{hook}", hookName)
+		}
+	}
+
+	return reports
+}
+
+func (l *Lefthook) writeReport(path string, write func(io.Writer) error) error {
+	if err := l.fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := l.fs.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err = write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
 func (l *Lefthook) logSummary(
 	exLogger *logger.ExecutionLogger,
 	duration time.Duration,
@@ -315,7 +455,7 @@ func logResults(indent int, exLogger *logger.ExecutionLogger, results []result.R
 				continue
 			}
 
-			exLogger.LogSuccess(indent, result.Name, result.Duration)
+			exLogger.LogSuccess(indent, result.Name, result.Duration, result.Attempts)
 
 			if len(result.Sub) > 0 {
 				logResults(indent+1, exLogger, result.Sub)
@@ -324,12 +464,36 @@ func logResults(indent int, exLogger *logger.ExecutionLogger, results []result.R
 	}
 
 	if exLogger.Enabled(logger.LogFailure) {
+		for _, result := range results {
+			if !result.Warning() {
+				continue
+			}
+
+			exLogger.LogWarning(indent, result.Name, result.Text(), result.Duration, result.Attempts)
+
+			if len(result.Sub) > 0 {
+				logResults(indent+1, exLogger, result.Sub)
+			}
+		}
+
 		for _, result := range results {
 			if !result.Failure() {
 				continue
 			}
 
-			exLogger.LogFailure(indent, result.Name, result.Text(), result.Duration)
+			exLogger.LogFailure(indent, result.Name, result.Text(), result.Duration, result.Attempts)
+
+			if len(result.Sub) > 0 {
+				logResults(indent+1, exLogger, result.Sub)
+			}
+		}
+
+		for _, result := range results {
+			if !result.Cancelled() {
+				continue
+			}
+
+			exLogger.LogCancelled(indent, result.Name, result.Duration)
 
 			if len(result.Sub) > 0 {
 				logResults(indent+1, exLogger, result.Sub)
@@ -3,6 +3,8 @@ package command
 import (
 	"context"
 	"errors"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/kaptinlin/jsonschema"
@@ -44,10 +46,39 @@ func (l *Lefthook) Validate(_ctx context.Context, args ValidateArgs) error {
 		return errors.New("validation failed for secondary config")
 	}
 
+	cfg, err := loader.Unmarshal(main, secondary)
+	if err != nil {
+		return err
+	}
+
+	if !l.validateJobs(cfg) {
+		return errors.New("validation failed for jobs")
+	}
+
 	l.logger.Info("All good")
 	return nil
 }
 
+// validateJobs checks the settings which can't be expressed with JSON schema.
+func (l *Lefthook) validateJobs(cfg *config.Config) bool {
+	valid := true
+	for _, hookName := range slices.Sorted(maps.Keys(cfg.Hooks)) {
+		hook := cfg.Hooks[hookName]
+		errs := config.ValidateNeeds(hook.Jobs)
+		errs = append(errs, config.ValidateIf(hook, cfg.GlobMatcher)...)
+		errs = append(errs, config.ValidateLintCommitMsg(hook.Jobs)...)
+		for _, err := range errs {
+			valid = false
+			l.logger.Info(
+				l.logger.Paint(logger.ColorYellow, hookName+": "),
+				l.logger.Paint(logger.ColorRed, err.Error()),
+			)
+		}
+	}
+
+	return valid
+}
+
 func (l *Lefthook) logValidationErrors(indent int, details jsonschema.List) {
 	if details.Valid {
 		return
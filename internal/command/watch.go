@@ -0,0 +1,316 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/afero"
+)
+
+const (
+	watchDebounce = 500 * time.Millisecond
+
+	// watchSettle is the time to wait for the events caused by the hook itself, e.g. formatters.
+	watchSettle = 100 * time.Millisecond
+)
+
+type WatchArgs struct {
+	Hook    string
+	Verbose bool
+}
+
+// watchChanges are the files changed since the previous run.
+type watchChanges struct {
+	files   []string
+	deleted []string
+}
+
+// watcher tracks the changes of the files which are not ignored by git.
+type watcher struct {
+	*fsnotify.Watcher
+
+	lefthook *Lefthook
+
+	// files are the known files which are not ignored, relative to the root.
+	files map[string]struct{}
+
+	// touched are the paths from the events since the previous run.
+	touched map[string]struct{}
+}
+
+// Watch watches the worktree for changes and runs the hook for the changed
+// files. Only the jobs matching the changed files are executed.
+func (l *Lefthook) Watch(ctx context.Context, args WatchArgs) error {
+	cfg, err := l.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.Hooks[args.Hook]; !ok {
+		return fmt.Errorf("hook %s doesn't exist in the config", args.Hook)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	w, err := l.newWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cErr := w.Close(); cErr != nil {
+			l.logger.Warnf("Could not stop watching: %s", cErr)
+		}
+	}()
+
+	l.logger.Infof("Watching for changes to run %s. Press Ctrl+C to stop.\n", args.Hook)
+
+	return w.watch(ctx, func(changes watchChanges) {
+		l.repo.ResetCache()
+		err := l.Run(ctx, RunArgs{
+			Hook:              args.Hook,
+			Verbose:           args.Verbose,
+			Files:             changes.files,
+			DeletedFiles:      changes.deleted,
+			OnlyMatchingFiles: true,
+			NoAutoInstall:     true,
+			NoStageFixed:      true,
+			SkipLFS:           true,
+		})
+		if err != nil && len(err.Error()) > 0 {
+			l.logger.Error(err)
+		}
+	})
+}
+
+// newWatcher starts watching the directories of the files not ignored by git.
+func (l *Lefthook) newWatcher() (*watcher, error) {
+	files, err := l.repo.WorktreeFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &watcher{
+		Watcher:  fsWatcher,
+		lefthook: l,
+		files:    make(map[string]struct{}, len(files)),
+		touched:  make(map[string]struct{}),
+	}
+
+	dirs := map[string]struct{}{".": {}}
+	for _, file := range files {
+		w.files[file] = struct{}{}
+		for dir := filepath.Dir(file); dir != "."; dir = filepath.Dir(dir) {
+			dirs[dir] = struct{}{}
+		}
+	}
+
+	for dir := range dirs {
+		if err = w.Add(filepath.Join(l.repo.RootPath, dir)); err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// watch calls run for the changed files when they stop changing.
+func (w *watcher) watch(ctx context.Context, run func(watchChanges)) error {
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			w.lefthook.logger.Warnf("Watch error: %s", err)
+		case event, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			w.handle(event)
+			debounce.Reset(watchDebounce)
+		case <-debounce.C:
+			changes, err := w.changes()
+			if err != nil {
+				return err
+			}
+			if len(changes.files) == 0 && len(changes.deleted) == 0 {
+				continue
+			}
+
+			run(changes)
+
+			// Jobs could have changed the files, e.g. formatters, don't react on that.
+			// Other changes made during the run are kept for the next run
+			w.settle(ctx)
+			w.forget(changes.files)
+			if len(w.touched) > 0 {
+				debounce.Reset(watchDebounce)
+			}
+		}
+	}
+}
+
+// handle remembers the path of the event and starts watching new directories.
+func (w *watcher) handle(event fsnotify.Event) {
+	rel, ok := w.relPath(event.Name)
+	if !ok {
+		return
+	}
+
+	w.touched[rel] = struct{}{}
+
+	if !event.Has(fsnotify.Create) {
+		return
+	}
+
+	info, err := w.lefthook.fs.Stat(event.Name)
+	if err != nil || !info.IsDir() {
+		return
+	}
+
+	// Don't watch ignored directories, e.g. node_modules
+	if ignored, err := w.lefthook.repo.IsIgnored(rel); err != nil || ignored {
+		return
+	}
+
+	// Files could be created before the directory is watched
+	_ = afero.Walk(w.lefthook.fs, event.Name, func(path string, info fs.FileInfo, err error) error {
+		// The directory could be removed already
+		if err != nil {
+			return filepath.SkipDir
+		}
+
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if !info.IsDir() {
+			if rel, ok := w.relPath(path); ok {
+				w.touched[rel] = struct{}{}
+			}
+			return nil
+		}
+
+		if err := w.Add(path); err != nil {
+			w.lefthook.logger.Debugf("Couldn't watch %s: %s", path, err)
+		}
+
+		return nil
+	})
+}
+
+// relPath returns the path relative to the root in git format. Paths inside
+// .git are skipped.
+func (w *watcher) relPath(path string) (string, bool) {
+	rel, err := filepath.Rel(w.lefthook.repo.RootPath, path)
+	if err != nil {
+		return "", false
+	}
+
+	rel = filepath.ToSlash(rel)
+	if rel == ".git" || strings.HasPrefix(rel, ".git/") {
+		return "", false
+	}
+
+	return rel, true
+}
+
+// settle drops the events until the files stop changing.
+func (w *watcher) settle(ctx context.Context) {
+	timer := time.NewTimer(watchSettle)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			return
+		case event, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			w.handle(event)
+			timer.Reset(watchSettle)
+		}
+	}
+}
+
+// forget drops the events of the files which still exist. Deleted files are
+// kept to be reported.
+func (w *watcher) forget(files []string) {
+	for _, file := range files {
+		if _, ok := w.touched[file]; !ok {
+			continue
+		}
+
+		if _, err := w.lefthook.fs.Stat(filepath.Join(w.lefthook.repo.RootPath, file)); err == nil {
+			delete(w.touched, file)
+		}
+	}
+}
+
+// changes returns the changed and the deleted files from the touched paths
+// and updates the known files.
+func (w *watcher) changes() (watchChanges, error) {
+	var changes watchChanges
+	if len(w.touched) == 0 {
+		return changes, nil
+	}
+
+	var existing []string
+	for _, path := range slices.Sorted(maps.Keys(w.touched)) {
+		info, err := w.lefthook.fs.Stat(filepath.Join(w.lefthook.repo.RootPath, path))
+		if err == nil {
+			if !info.IsDir() {
+				existing = append(existing, path)
+			}
+			continue
+		}
+
+		// The path is a removed file or directory
+		for file := range w.files {
+			if file == path || strings.HasPrefix(file, path+"/") {
+				delete(w.files, file)
+				changes.deleted = append(changes.deleted, file)
+			}
+		}
+	}
+	clear(w.touched)
+
+	files, err := w.lefthook.repo.WorktreeFilesIn(existing)
+	if err != nil {
+		return changes, err
+	}
+
+	for _, file := range files {
+		w.files[file] = struct{}{}
+	}
+
+	changes.files = files
+	slices.Sort(changes.deleted)
+
+	return changes, nil
+}
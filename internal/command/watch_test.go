@@ -0,0 +1,161 @@
+package command
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+func TestWatcher(t *testing.T) {
+	for name, tt := range map[string]struct {
+		change func(t *testing.T, root string)
+		want   watchChanges
+	}{
+		"modified file": {
+			change: func(t *testing.T, root string) {
+				t.Helper()
+				assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0o644))
+			},
+			want: watchChanges{files: []string{"main.go"}},
+		},
+		"deleted file": {
+			change: func(t *testing.T, root string) {
+				t.Helper()
+				assert.NoError(t, os.Remove(filepath.Join(root, "README.md")))
+			},
+			want: watchChanges{deleted: []string{"README.md"}},
+		},
+		"renamed file": {
+			change: func(t *testing.T, root string) {
+				t.Helper()
+				assert.NoError(t, os.Rename(filepath.Join(root, "lib", "util.go"), filepath.Join(root, "util.go")))
+			},
+			want: watchChanges{files: []string{"util.go"}, deleted: []string{"lib/util.go"}},
+		},
+		"new directory": {
+			change: func(t *testing.T, root string) {
+				t.Helper()
+				assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "api"), 0o755))
+				assert.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "api", "api.go"), []byte("package api"), 0o644))
+			},
+			want: watchChanges{files: []string{"pkg/api/api.go"}},
+		},
+		"deleted directory": {
+			change: func(t *testing.T, root string) {
+				t.Helper()
+				assert.NoError(t, os.RemoveAll(filepath.Join(root, "lib")))
+			},
+			want: watchChanges{deleted: []string{"lib/lib.go", "lib/util.go"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			w := newTestWatcher(t, root)
+			defer w.Close()
+
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			runs := make(chan watchChanges, 1)
+			done := make(chan error)
+			go func() {
+				done <- w.watch(ctx, func(changes watchChanges) {
+					runs <- changes
+					cancel()
+				})
+			}()
+
+			tt.change(t, root)
+
+			select {
+			case changes := <-runs:
+				assert.Equal(t, tt.want, changes)
+			case <-ctx.Done():
+				t.Fatal("the hook wasn't run")
+			}
+			assert.NoError(t, <-done)
+		})
+	}
+}
+
+func TestWatcherChangesDuringRun(t *testing.T) {
+	root := t.TempDir()
+	w := newTestWatcher(t, root)
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	var runs []watchChanges
+	done := make(chan error)
+	go func() {
+		done <- w.watch(ctx, func(changes watchChanges) {
+			runs = append(runs, changes)
+			if len(runs) > 1 {
+				cancel()
+				return
+			}
+
+			// A job rewrites the file it was run for, and another file is
+			// edited meanwhile
+			assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o644))
+			assert.NoError(t, os.WriteFile(filepath.Join(root, "README.md"), []byte("# readme"), 0o644))
+		})
+	}()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0o644))
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []watchChanges{
+		{files: []string{"main.go"}},
+		{files: []string{"README.md"}},
+	}, runs)
+}
+
+// newTestWatcher creates the files in the root and starts watching them.
+func newTestWatcher(t *testing.T, root string) *watcher {
+	t.Helper()
+
+	for _, file := range []string{"README.md", "main.go", "lib/lib.go", "lib/util.go"} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, file)), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, file), []byte("package"), 0o644))
+	}
+
+	cmd := cmdtest.NewTracking(func(command string, _root string, out io.Writer) error {
+		switch {
+		case command == "git ls-files --cached --others --exclude-standard":
+			_, err := io.WriteString(out, "README.md\nmain.go\nlib/lib.go\nlib/util.go")
+			return err
+		case strings.HasPrefix(command, "git --literal-pathspecs ls-files --cached"):
+			// Nothing is ignored
+			_, paths, _ := strings.Cut(command, " -- ")
+			_, err := io.WriteString(out, strings.ReplaceAll(paths, " ", "\n"))
+			return err
+		}
+
+		return nil
+	})
+
+	fs := afero.NewOsFs()
+	lefthook := &Lefthook{
+		logger: loggertest.New(),
+		fs:     fs,
+		repo:   gittest.NewRepositoryBuilder().Cmd(cmd).Fs(fs).Root(root).Build(),
+	}
+
+	w, err := lefthook.newWatcher()
+	assert.NoError(t, err)
+
+	return w
+}
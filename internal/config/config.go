@@ -52,14 +52,20 @@ type Config struct {
 
 	InstallNonGitHooks bool `json:"install_non_git_hooks,omitempty" jsonschema:"description=Install non-Git hooks to .git/hooks" koanf:"install_non_git_hooks" mapstructure:"install_non_git_hooks,omitempty"`
 
+	ChainExistingHooks bool `json:"chain_existing_hooks,omitempty" jsonschema:"description=Run the existing Git hooks saved with .old suffix before lefthook" koanf:"chain_existing_hooks" mapstructure:"chain_existing_hooks,omitempty"`
+
 	GlobMatcher string `json:"glob_matcher,omitempty" jsonschema:"description=Choose the glob matching engine: 'gobwas' (default) or 'doublestar' (standard ** behavior),enum=gobwas,enum=doublestar,default=gobwas" koanf:"glob_matcher" mapstructure:"glob_matcher,omitempty"`
 
+	LockMismatch string `json:"lock_mismatch,omitempty" jsonschema:"description=What to do when a remote checkout differs from lefthook.lock: 'error' (default) or 'warn',enum=error,enum=warn,default=error" koanf:"lock_mismatch" mapstructure:"lock_mismatch,omitempty"`
+
 	Remotes []*Remote `json:"remotes,omitempty" jsonschema:"description=Provide multiple remote configs to use lefthook configurations shared across projects. Lefthook will automatically download and merge configurations into main config." mapstructure:"remotes,omitempty"`
 
 	Templates map[string]string `json:"templates,omitempty" jsonschema:"description=Custom templates for replacements in run commands." mapstructure:"templates,omitempty"`
 
 	AI *AI `json:"ai,omitempty" jsonschema:"description=LLM agent hook integration. Generates provider-specific settings files during lefthook install." mapstructure:"ai,omitempty" toml:"ai,omitempty" yaml:"ai,omitempty"`
 
+	Report *Report `json:"report,omitempty" jsonschema:"description=Write reports of the hook runs to files. {hook} in a path is replaced with the hook name." mapstructure:"report,omitempty" toml:"report,omitempty" yaml:"report,omitempty"`
+
 	Hooks map[string]*Hook `jsonschema:"-" mapstructure:"-"`
 }
 
@@ -0,0 +1,356 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/knadh/koanf/v2"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/logger"
+)
+
+// Explanation tells which config file set each value of the merged config.
+type Explanation struct {
+	history map[string][]Change
+}
+
+// Change is a value set by a config file.
+type Change struct {
+	Source string
+	Value  any
+
+	layer int
+}
+
+// tracer takes a snapshot of the merged config after each config file is
+// loaded. The changes between the snapshots are attributed to the files.
+type tracer struct {
+	logger *logger.Logger
+	root   string
+
+	main, secondary *koanf.Koanf
+
+	// Remote which configs are being loaded.
+	remote, remoteFolder string
+
+	layers []traceLayer
+}
+
+type traceLayer struct {
+	source string
+	values map[string]any
+}
+
+// Explain loads the config tracking the config files setting each value.
+func (l *Loader) Explain() (*Config, *Explanation, error) {
+	l.tracer = &tracer{logger: l.logger, root: l.repo.RootPath}
+	defer func() { l.tracer = nil }()
+
+	config, err := l.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, l.tracer.explanation(), nil
+}
+
+// History returns the values set for the path in the order of loading.
+func (e *Explanation) History(path string) []Change {
+	return e.history[path]
+}
+
+// DumpExplained dumps the config in YAML format annotating the values with
+// the files that set them, and the overridden values.
+func (c *Config) DumpExplained(e *Explanation, out io.Writer) error {
+	var buf bytes.Buffer
+	if err := c.Dump(YAMLFormat, &buf); err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return err
+	}
+
+	if len(doc.Content) > 0 {
+		e.annotate(doc.Content[0], "")
+	}
+
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(yamlIndent)
+
+	return errors.Join(encoder.Encode(&doc), encoder.Close())
+}
+
+func (t *tracer) setMain(main *koanf.Koanf) {
+	if t != nil {
+		t.main = main
+	}
+}
+
+func (t *tracer) setSecondary(secondary *koanf.Koanf) {
+	if t != nil {
+		t.secondary = secondary
+	}
+}
+
+func (t *tracer) setRemote(remote, folder string) {
+	if t != nil {
+		t.remote, t.remoteFolder = remote, folder
+	}
+}
+
+// trace takes a snapshot of the config after the file is loaded into k.
+// Loads into the intermediate koanf instances are skipped.
+func (t *tracer) trace(k *koanf.Koanf, path string) {
+	if t == nil || t.main == nil || (k != t.main && k != t.secondary) {
+		return
+	}
+
+	main := t.main.Copy()
+	secondary := koanf.New(".")
+	if t.secondary != nil {
+		secondary = t.secondary.Copy()
+	}
+
+	var config Config
+	config.SourceDir = DefaultSourceDir
+	config.SourceDirLocal = DefaultSourceDirLocal
+	if err := unmarshalConfigs(main, secondary, &config); err != nil {
+		t.logger.Debugf("couldn't trace %s: %s", path, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := config.Dump(YAMLFormat, &buf); err != nil {
+		t.logger.Debugf("couldn't trace %s: %s", path, err)
+		return
+	}
+
+	var raw any
+	if err := yaml.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.logger.Debugf("couldn't trace %s: %s", path, err)
+		return
+	}
+
+	values := make(map[string]any)
+	flatten(raw, "", values)
+	t.layers = append(t.layers, traceLayer{source: t.source(path), values: values})
+}
+
+func (t *tracer) source(path string) string {
+	if len(t.remote) > 0 {
+		if rel, err := filepath.Rel(t.remoteFolder, path); err == nil {
+			path = rel
+		}
+
+		return t.remote + ": " + filepath.ToSlash(path)
+	}
+
+	if rel, err := filepath.Rel(t.root, path); err == nil {
+		path = rel
+	}
+
+	return filepath.ToSlash(path)
+}
+
+func (t *tracer) explanation() *Explanation {
+	e := &Explanation{history: make(map[string][]Change)}
+
+	previous := make(map[string]any)
+	for i, layer := range t.layers {
+		for path, value := range layer.values {
+			if old, ok := previous[path]; ok && reflect.DeepEqual(old, value) {
+				continue
+			}
+
+			e.history[path] = append(e.history[path], Change{Source: layer.source, Value: value, layer: i})
+		}
+		previous = layer.values
+	}
+
+	return e
+}
+
+// sources returns the files which set the values inside the path.
+func (e *Explanation) sources(path string) []string {
+	var changes []Change
+	for p, history := range e.history {
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			changes = append(changes, history...)
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b Change) int { return a.layer - b.layer })
+
+	var sources []string
+	for _, change := range changes {
+		if !slices.Contains(sources, change.Source) {
+			sources = append(sources, change.Source)
+		}
+	}
+
+	return sources
+}
+
+func (e *Explanation) annotate(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			valuePath := joinPath(path, key.Value)
+
+			// Named items are already annotated
+			if key.Value == "name" && strings.HasSuffix(path, "]") {
+				continue
+			}
+
+			if isLeafNode(value) {
+				e.annotateLeaf(key, value, valuePath)
+				continue
+			}
+
+			key.LineComment = strings.Join(e.sources(valuePath), ", ")
+			e.annotate(value, valuePath)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := path + itemSegment(nodeName(item), i)
+			if isLeafNode(item) {
+				if history := e.history[itemPath]; len(history) > 0 {
+					item.LineComment = history[len(history)-1].Source
+				}
+				continue
+			}
+
+			item.HeadComment = strings.Join(e.sources(itemPath), ", ")
+			e.annotate(item, itemPath)
+		}
+	default:
+	}
+}
+
+func (e *Explanation) annotateLeaf(key, value *yaml.Node, path string) {
+	history := e.history[path]
+	if len(history) == 0 {
+		return
+	}
+
+	if value.Kind == yaml.ScalarNode {
+		value.LineComment = history[len(history)-1].Source
+	} else {
+		key.LineComment = history[len(history)-1].Source
+	}
+
+	overridden := make([]string, 0, len(history)-1)
+	for _, change := range history[:len(history)-1] {
+		overridden = append(overridden, fmt.Sprintf("overrides %s from %s", formatValue(change.Value), change.Source))
+	}
+	key.HeadComment = strings.Join(overridden, "\n")
+}
+
+// flatten collects the leaf values of the config by their paths. The items of
+// the lists are identified by names if they have them, e.g. `pre-commit.jobs["lint"].run`.
+func flatten(value any, path string, values map[string]any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			flatten(child, joinPath(path, key), values)
+		}
+	case []any:
+		if isLeafList(v) {
+			values[path] = v
+			return
+		}
+
+		for i, item := range v {
+			var name string
+			if m, ok := item.(map[string]any); ok {
+				name, _ = m["name"].(string)
+			}
+			flatten(item, path+itemSegment(name, i), values)
+		}
+	default:
+		values[path] = v
+	}
+}
+
+func isLeafList(list []any) bool {
+	for _, item := range list {
+		switch item.(type) {
+		case map[string]any, []any:
+			return false
+		default:
+		}
+	}
+
+	return true
+}
+
+func isLeafNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return false
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.MappingNode || item.Kind == yaml.SequenceNode {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+func nodeName(node *yaml.Node) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "name" && node.Content[i+1].Kind == yaml.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+
+	return ""
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]\"") {
+		key = strconv.Quote(key)
+	}
+
+	if len(path) == 0 {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func itemSegment(name string, index int) string {
+	if len(name) > 0 {
+		return "[" + strconv.Quote(name) + "]"
+	}
+
+	return "[" + strconv.Itoa(index) + "]"
+}
+
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
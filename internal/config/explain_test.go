@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+func TestLoaderExplain(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	remotePath := filepath.Join(root, ".git", "info", "lefthook-remotes", "configs")
+
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		filepath.Join(root, "lefthook.yml"): `
+min_version: 1.0.0
+extends:
+  - shared/common.yml
+remotes:
+  - git_url: https://github.com/evilmartians/configs
+pre-commit:
+  jobs:
+    - name: lint
+      run: yarn lint
+      glob: "*.js"
+`,
+		filepath.Join(root, "shared", "common.yml"): `
+pre-push:
+  jobs:
+    - name: audit
+      run: yarn audit
+`,
+		filepath.Join(remotePath, "lefthook.yml"): `
+pre-push:
+  follow: true
+`,
+		filepath.Join(root, "lefthook-local.yml"): `
+pre-commit:
+  jobs:
+    - name: lint
+      run: yarn lint --fix
+`,
+	} {
+		assert.NoError(t, afero.WriteFile(fs, path, []byte(content), 0o644))
+	}
+
+	repo := gittest.NewRepositoryBuilder().Root(root).Fs(fs).Build()
+	config, explanation, err := NewLoader(repo, loggertest.New()).Explain()
+	assert.NoError(t, err)
+
+	history := explanation.History(`pre-commit.jobs["lint"].run`)
+	assert.Equal(t, []string{"lefthook.yml", "lefthook-local.yml"}, []string{history[0].Source, history[1].Source})
+	assert.Equal(t, "yarn lint", history[0].Value)
+
+	var out bytes.Buffer
+	assert.NoError(t, config.DumpExplained(explanation, &out))
+	assert.Equal(t, `min_version: 1.0.0 # lefthook.yml
+pre-commit: # lefthook.yml, lefthook-local.yml
+  jobs: # lefthook.yml, lefthook-local.yml
+    # lefthook.yml, lefthook-local.yml
+    - name: lint
+      # overrides "yarn lint" from lefthook.yml
+      run: yarn lint --fix # lefthook-local.yml
+      glob: # lefthook.yml
+        - '*.js'
+pre-push: # shared/common.yml, https://github.com/evilmartians/configs: lefthook.yml
+  follow: true # https://github.com/evilmartians/configs: lefthook.yml
+  jobs: # shared/common.yml
+    # shared/common.yml
+    - name: audit
+      run: yarn audit # shared/common.yml
+remotes: # lefthook.yml
+  # lefthook.yml
+  - git_url: https://github.com/evilmartians/configs # lefthook.yml
+`, out.String())
+}
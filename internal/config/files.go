@@ -3,10 +3,11 @@ package config
 import "strings"
 
 const (
-	SubFiles       string = "{files}"
-	SubAllFiles    string = "{all_files}"
-	SubStagedFiles string = "{staged_files}"
-	SubPushFiles   string = "{push_files}"
+	SubFiles        string = "{files}"
+	SubAllFiles     string = "{all_files}"
+	SubStagedFiles  string = "{staged_files}"
+	SubPushFiles    string = "{push_files}"
+	SubChangedFiles string = "{changed_files}"
 )
 
 func IsRunFilesCompatible(run string) bool {
@@ -9,11 +9,14 @@ type Hook struct {
 	Follow            bool     `json:"follow,omitempty"               mapstructure:"follow"                                                                      toml:"follow,omitempty"             yaml:",omitempty"`
 	FailOnChanges     string   `json:"fail_on_changes,omitempty"      jsonschema:"enum=true,enum=1,enum=0,enum=false,enum=never,enum=always,enum=ci,enum=non-ci" koanf:"fail_on_changes"             mapstructure:"fail_on_changes"        toml:"fail_on_changes,omitempty"      yaml:"fail_on_changes,omitempty"`
 	FailOnChangesDiff *bool    `json:"fail_on_changes_diff,omitempty" koanf:"fail_on_changes_diff"                                                               mapstructure:"fail_on_changes_diff" toml:"fail_on_changes_diff,omitempty" yaml:"fail_on_changes_diff,omitempty"`
+	FailFast          bool     `json:"fail_fast,omitempty"            koanf:"fail_fast"                                                                          mapstructure:"fail_fast"            toml:"fail_fast,omitempty"            yaml:"fail_fast,omitempty"`
 	Files             string   `json:"files,omitempty"                mapstructure:"files"                                                                       toml:"files,omitempty"              yaml:",omitempty"`
+	MaxParallel       int      `json:"max_parallel,omitempty"         jsonschema:"minimum=1"                                                                     koanf:"max_parallel"                mapstructure:"max_parallel"           toml:"max_parallel,omitempty"         yaml:"max_parallel,omitempty"`
 	ExcludeTags       []string `json:"exclude_tags,omitempty"         koanf:"exclude_tags"                                                                       mapstructure:"exclude_tags"         toml:"exclude_tags,omitempty"         yaml:"exclude_tags,omitempty"`
 	Exclude           []string `json:"exclude,omitempty"              koanf:"exclude"                                                                            mapstructure:"exclude"              toml:"exclude,omitempty"              yaml:"exclude,omitempty"`
 	Skip              any      `json:"skip,omitempty"                 jsonschema:"oneof_type=boolean;array"                                                      mapstructure:"skip"                 toml:"skip,omitempty,inline"          yaml:",omitempty"`
 	Only              any      `json:"only,omitempty"                 jsonschema:"oneof_type=boolean;array"                                                      mapstructure:"only"                 toml:"only,omitempty,inline"          yaml:",omitempty"`
+	If                string   `json:"if,omitempty"                   mapstructure:"if"                                                                          toml:"if,omitempty"                 yaml:",omitempty"`
 
 	Setup []*SetupInstruction `json:"setup,omitempty" mapstructure:"setup" toml:"setup,omitempty" yaml:",omitempty"`
 	Jobs  []*Job              `json:"jobs,omitempty"  mapstructure:"jobs"  toml:"jobs,omitempty"  yaml:",omitempty"`
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/evilmartians/lefthook/v2/internal/expr"
+)
+
+// ValidateIf checks `if` expressions of the hook and its jobs.
+func ValidateIf(hook *Hook, globMatcher string) []error {
+	var errs []error
+
+	if len(hook.If) > 0 {
+		if _, err := expr.Parse(hook.If, globMatcher); err != nil {
+			errs = append(errs, fmt.Errorf("if: %w", err))
+		}
+	}
+
+	return append(errs, validateJobsIf(hook.Jobs, globMatcher)...)
+}
+
+func validateJobsIf(jobs []*Job, globMatcher string) []error {
+	var errs []error
+
+	for i, job := range jobs {
+		if len(job.If) > 0 {
+			if _, err := expr.Parse(job.If, globMatcher); err != nil {
+				errs = append(errs, fmt.Errorf("job %q: if: %w", job.PrintableName(fmt.Sprint(i)), err))
+			}
+		}
+
+		if job.Group != nil {
+			errs = append(errs, validateJobsIf(job.Group.Jobs, globMatcher)...)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIf(t *testing.T) {
+	hook := &Hook{
+		If: `env.CI ==`,
+		Jobs: []*Job{
+			{Name: "lint", If: `branch =~ "release/*" && files.changed("*.go")`},
+			{Name: "test", If: `git.branch == "main"`},
+			{
+				Name: "group",
+				Group: &Group{
+					Jobs: []*Job{
+						{Run: "make", If: `exists(true)`},
+					},
+				},
+			},
+		},
+	}
+
+	errs := ValidateIf(hook, "")
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "if: unexpected end of expression at position 10")
+	assert.EqualError(t, errs[1], `job "test": if: unknown variable "git.branch" at position 1`)
+	assert.EqualError(t, errs[2], `job "make": if: exists expects a string at position 1`)
+}
@@ -12,29 +12,44 @@ type Job struct {
 	Files    string        `json:"files,omitempty"     mapstructure:"files"                      toml:"files,omitempty"   yaml:",omitempty"`
 	FailText string        `json:"fail_text,omitempty" koanf:"fail_text"                         mapstructure:"fail_text" toml:"fail_text,omitempty" yaml:"fail_text,omitempty"`
 	Timeout  time.Duration `json:"timeout,omitempty"   jsonschema:"type=string,example=15s"      mapstructure:"timeout"   toml:"timeout,omitempty"   yaml:",omitempty"`
+	If       string        `json:"if,omitempty"        mapstructure:"if"                         toml:"if,omitempty"      yaml:",omitempty"`
+
+	Retries    int           `json:"retries,omitempty"     jsonschema:"minimum=0"              mapstructure:"retries" toml:"retries,omitempty"   yaml:",omitempty"`
+	RetryDelay time.Duration `json:"retry_delay,omitempty" jsonschema:"type=string,example=2s" koanf:"retry_delay"    mapstructure:"retry_delay" toml:"retry_delay,omitempty" yaml:"retry_delay,omitempty"`
 
 	Glob      []string `json:"glob,omitempty"       jsonschema:"oneof_type=string;array" mapstructure:"glob"    toml:"glob,omitempty"     yaml:",omitempty"`
 	Exclude   []string `json:"exclude,omitempty"    jsonschema:"oneof_type=string;array" mapstructure:"exclude" toml:"exclude,omitempty"  yaml:",omitempty"`
 	Tags      []string `json:"tags,omitempty"       mapstructure:"tags"                  toml:"tags,omitempty"  yaml:",omitempty"`
 	FileTypes []string `json:"file_types,omitempty" jsonschema:"oneof_type=string;array" koanf:"file_types"     mapstructure:"file_types" toml:"file_types,omitempty" yaml:"file_types,omitempty"`
+	Needs     []string `json:"needs,omitempty"      jsonschema:"oneof_type=string;array" mapstructure:"needs"   toml:"needs,omitempty"    yaml:",omitempty"`
+	Locks     []string `json:"locks,omitempty"      jsonschema:"oneof_type=string;array" mapstructure:"locks"   toml:"locks,omitempty"    yaml:",omitempty"`
+	Outputs   []string `json:"outputs,omitempty"    jsonschema:"oneof_type=string;array" mapstructure:"outputs" toml:"outputs,omitempty"  yaml:",omitempty"`
 
-	Env map[string]string `json:"env,omitempty" mapstructure:"env" toml:"env,omitempty" yaml:",omitempty"`
+	Env    map[string]string   `json:"env,omitempty"    mapstructure:"env"    toml:"env,omitempty"    yaml:",omitempty"`
+	Matrix map[string][]string `json:"matrix,omitempty" mapstructure:"matrix" toml:"matrix,omitempty" yaml:",omitempty"`
 
 	Interactive bool `json:"interactive,omitempty" mapstructure:"interactive" toml:"interactive,omitempty" yaml:",omitempty"`
 	UseStdin    bool `json:"use_stdin,omitempty"   koanf:"use_stdin"          mapstructure:"use_stdin"     toml:"use_stdin,omitempty"   yaml:"use_stdin,omitempty"`
 	StageFixed  bool `json:"stage_fixed,omitempty" koanf:"stage_fixed"        mapstructure:"stage_fixed"   toml:"stage_fixed,omitempty" yaml:"stage_fixed,omitempty"`
 
+	AllowFailure bool `json:"allow_failure,omitempty" koanf:"allow_failure" mapstructure:"allow_failure" toml:"allow_failure,omitempty" yaml:"allow_failure,omitempty"`
+	Cache        bool `json:"cache,omitempty"         mapstructure:"cache"  toml:"cache,omitempty"       yaml:",omitempty"`
+
 	Skip any `json:"skip,omitempty" jsonschema:"oneof_type=boolean;array" mapstructure:"skip" toml:"skip,omitempty,inline" yaml:",omitempty"`
 	Only any `json:"only,omitempty" jsonschema:"oneof_type=boolean;array" mapstructure:"only" toml:"only,omitempty,inline" yaml:",omitempty"`
 
 	Group *Group `json:"group,omitempty" jsonschema:"oneof_required=Run a group" mapstructure:"group" toml:"group,omitempty" yaml:",omitempty"`
+
+	LintCommitMsg *LintCommitMsg `json:"lint_commit_msg,omitempty" jsonschema:"oneof_required=Lint a commit message" koanf:"lint_commit_msg" mapstructure:"lint_commit_msg" toml:"lint_commit_msg,omitempty" yaml:"lint_commit_msg,omitempty"`
 }
 
 type Group struct {
-	Root     string `json:"root,omitempty"     mapstructure:"root"     toml:"root,omitempty"     yaml:",omitempty"`
-	Parallel bool   `json:"parallel,omitempty" mapstructure:"parallel" toml:"parallel,omitempty" yaml:",omitempty"`
-	Piped    bool   `json:"piped,omitempty"    mapstructure:"piped"    toml:"piped,omitempty"    yaml:",omitempty"`
-	Jobs     []*Job `json:"jobs"               mapstructure:"jobs"     toml:"jobs"               yaml:"jobs"`
+	Root        string `json:"root,omitempty"         mapstructure:"root"     toml:"root,omitempty"     yaml:",omitempty"`
+	Parallel    bool   `json:"parallel,omitempty"     mapstructure:"parallel" toml:"parallel,omitempty" yaml:",omitempty"`
+	Piped       bool   `json:"piped,omitempty"        mapstructure:"piped"    toml:"piped,omitempty"    yaml:",omitempty"`
+	FailFast    bool   `json:"fail_fast,omitempty"    koanf:"fail_fast"       mapstructure:"fail_fast"  toml:"fail_fast,omitempty"  yaml:"fail_fast,omitempty"`
+	MaxParallel int    `json:"max_parallel,omitempty" jsonschema:"minimum=1"  koanf:"max_parallel"      mapstructure:"max_parallel" toml:"max_parallel,omitempty" yaml:"max_parallel,omitempty"`
+	Jobs        []*Job `json:"jobs"                   mapstructure:"jobs"     toml:"jobs"               yaml:"jobs"`
 }
 
 func (job *Job) PrintableName(id string) string {
@@ -47,6 +62,9 @@ func (job *Job) PrintableName(id string) string {
 	if len(job.Script) != 0 {
 		return job.Script
 	}
+	if job.LintCommitMsg != nil {
+		return "lint_commit_msg"
+	}
 
 	return "[" + id + "]"
 }
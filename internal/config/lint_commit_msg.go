@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// DefaultCommitTypes are the commit types allowed by the Conventional Commits
+// linter when `types` are not set.
+var DefaultCommitTypes = []string{
+	"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test",
+}
+
+// LintCommitMsg contains the rules of the built-in Conventional Commits linter.
+type LintCommitMsg struct {
+	Types  []string `json:"types,omitempty"  mapstructure:"types"  toml:"types,omitempty"  yaml:",omitempty"`
+	Scopes []string `json:"scopes,omitempty" mapstructure:"scopes" toml:"scopes,omitempty" yaml:",omitempty"`
+	Ticket string   `json:"ticket,omitempty" mapstructure:"ticket" toml:"ticket,omitempty" yaml:",omitempty"`
+
+	ScopeRequired        bool `json:"scope_required,omitempty"         koanf:"scope_required"         mapstructure:"scope_required"         toml:"scope_required,omitempty"         yaml:"scope_required,omitempty"`
+	BreakingChangeFooter bool `json:"breaking_change_footer,omitempty" koanf:"breaking_change_footer" mapstructure:"breaking_change_footer" toml:"breaking_change_footer,omitempty" yaml:"breaking_change_footer,omitempty"`
+
+	SubjectMaxLength  int `json:"subject_max_length,omitempty"   jsonschema:"minimum=0" koanf:"subject_max_length"   mapstructure:"subject_max_length"   toml:"subject_max_length,omitempty"   yaml:"subject_max_length,omitempty"`
+	BodyMaxLineLength int `json:"body_max_line_length,omitempty" jsonschema:"minimum=0" koanf:"body_max_line_length" mapstructure:"body_max_line_length" toml:"body_max_line_length,omitempty" yaml:"body_max_line_length,omitempty"`
+}
+
+// ValidateLintCommitMsg checks `lint_commit_msg` settings of the jobs and all nested groups.
+func ValidateLintCommitMsg(jobs []*Job) []error {
+	var errs []error
+
+	for i, job := range jobs {
+		name := job.PrintableName(strconv.Itoa(i))
+
+		if job.LintCommitMsg != nil {
+			if len(job.Run) > 0 || len(job.Script) > 0 {
+				errs = append(errs, fmt.Errorf("job %q: lint_commit_msg: can't be used with run or script", name))
+			}
+
+			if len(job.LintCommitMsg.Ticket) > 0 {
+				if _, err := regexp.Compile(job.LintCommitMsg.Ticket); err != nil {
+					errs = append(errs, fmt.Errorf("job %q: lint_commit_msg: invalid ticket regexp: %w", name, err))
+				}
+			}
+		}
+
+		if job.Group != nil {
+			errs = append(errs, ValidateLintCommitMsg(job.Group.Jobs)...)
+		}
+	}
+
+	return errs
+}
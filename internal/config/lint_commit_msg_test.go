@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLintCommitMsg(t *testing.T) {
+	jobs := []*Job{
+		{Name: "valid", LintCommitMsg: &LintCommitMsg{Ticket: `[A-Z]+-\d+`}},
+		{Name: "with run", Run: "commitlint", LintCommitMsg: &LintCommitMsg{}},
+		{
+			Name: "group",
+			Group: &Group{
+				Jobs: []*Job{
+					{LintCommitMsg: &LintCommitMsg{Ticket: `(JIRA-\d+`}},
+				},
+			},
+		},
+	}
+
+	errs := ValidateLintCommitMsg(jobs)
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], `job "with run": lint_commit_msg: can't be used with run or script`)
+	assert.EqualError(t, errs[1], "job \"lint_commit_msg\": lint_commit_msg: invalid ticket regexp: error parsing regexp: missing closing ): `(JIRA-\\d+`")
+}
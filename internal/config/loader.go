@@ -63,8 +63,10 @@ func (err ConfigNotFoundError) Error() string {
 }
 
 type Loader struct {
-	repo   *git.Repo
-	logger *logger.Logger
+	repo     *git.Repo
+	logger   *logger.Logger
+	tracer   *tracer
+	skipLock bool
 }
 
 func NewLoader(repo *git.Repo, logger *logger.Logger) *Loader {
@@ -74,6 +76,13 @@ func NewLoader(repo *git.Repo, logger *logger.Logger) *Loader {
 	}
 }
 
+// SkipLock disables checking the remotes against lefthook.lock.
+func (l *Loader) SkipLock() *Loader {
+	l.skipLock = true
+
+	return l
+}
+
 // loadConfig loads the config at the given path.
 func (l *Loader) loadConfig(k *koanf.Koanf, path string) error {
 	extension := filepath.Ext(path)
@@ -82,6 +91,8 @@ func (l *Loader) loadConfig(k *koanf.Koanf, path string) error {
 		return err
 	}
 
+	l.tracer.trace(k, path)
+
 	return nil
 }
 
@@ -127,6 +138,7 @@ func (l *Loader) loadFirstMain(k *koanf.Koanf, root string) error {
 
 func (l *Loader) loadMain(root string) (*koanf.Koanf, error) {
 	main := koanf.New(".")
+	l.tracer.setMain(main)
 
 	configOverridePath := os.Getenv("LEFTHOOK_CONFIG")
 	if len(configOverridePath) == 0 {
@@ -160,9 +172,10 @@ func (l *Loader) LoadSecondary(main *koanf.Koanf) (*koanf.Koanf, error) {
 	}
 
 	secondary := koanf.New(".")
+	l.tracer.setSecondary(secondary)
 
 	// Load main `extends`
-	if err := extend(secondary, l.repo.Fs, l.repo.RootPath, extends); err != nil {
+	if err := l.extend(secondary, l.repo.RootPath, extends); err != nil {
 		return nil, err
 	}
 
@@ -173,7 +186,7 @@ func (l *Loader) LoadSecondary(main *koanf.Koanf) (*koanf.Koanf, error) {
 	}
 
 	// Load main `remotes`
-	if err := l.loadRemotes(secondary, remotes); err != nil {
+	if err := l.loadRemotes(secondary, remotes, main); err != nil {
 		return nil, err
 	}
 
@@ -192,7 +205,7 @@ func (l *Loader) LoadSecondary(main *koanf.Koanf) (*koanf.Koanf, error) {
 	// Load local `extends`
 	localExtends := secondary.Strings("extends")
 	if !noLocal && !slices.Equal(extends, localExtends) {
-		if err := extend(secondary, l.repo.Fs, l.repo.RootPath, localExtends); err != nil {
+		if err := l.extend(secondary, l.repo.RootPath, localExtends); err != nil {
 			return nil, err
 		}
 	}
@@ -200,6 +213,22 @@ func (l *Loader) LoadSecondary(main *koanf.Koanf) (*koanf.Koanf, error) {
 	return secondary, nil
 }
 
+// LoadRemotes returns the remotes configured in the main config without
+// loading them.
+func (l *Loader) LoadRemotes() ([]*Remote, error) {
+	main, err := l.loadMain(l.repo.RootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var remotes []*Remote
+	if err = main.Unmarshal("remotes", &remotes); err != nil {
+		return nil, err
+	}
+
+	return remotes, nil
+}
+
 func (l *Loader) LoadKoanf() (*koanf.Koanf, *koanf.Koanf, error) {
 	// Load main lefthook.yml
 	main, err := l.loadMain(l.repo.RootPath)
@@ -283,8 +312,19 @@ func (l *Loader) Unmarshal(main *koanf.Koanf, secondary *koanf.Koanf) (*Config,
 	return &config, nil
 }
 
-// loadRemotes merges remote configs to the current one.
-func (l *Loader) loadRemotes(k *koanf.Koanf, remotes []*Remote) error {
+// loadRemotes merges remote configs to the current one. When lefthook.lock
+// exists the remote checkouts are verified against it.
+func (l *Loader) loadRemotes(k *koanf.Koanf, remotes []*Remote, main *koanf.Koanf) error {
+	lock, err := ReadLock(l.repo.Fs, l.repo.RootPath)
+	if err != nil {
+		return err
+	}
+
+	sourceDir := main.String("source_dir")
+	if len(sourceDir) == 0 {
+		sourceDir = DefaultSourceDir
+	}
+
 	for _, remote := range remotes {
 		if !remote.Configured() {
 			continue
@@ -294,12 +334,21 @@ func (l *Loader) loadRemotes(k *koanf.Koanf, remotes []*Remote) error {
 			remote.Configs = append(remote.Configs, DefaultConfigName)
 		}
 
+		remotePath := remote.Folder(l.repo)
+		l.tracer.setRemote(remote.Source(), remotePath)
+		if lock != nil && !l.skipLock && remote.Fetched() {
+			if ok, _ := afero.DirExists(l.repo.Fs, remotePath); ok {
+				if err := l.checkLock(lock, remote, sourceDir, main.String("lock_mismatch")); err != nil {
+					return err
+				}
+			}
+		}
+
 		for _, config := range remote.Configs {
-			remotePath := l.repo.RemoteFolder(remote.GitURL, remote.Ref)
 			configFile := config
 			configPath := filepath.Join(remotePath, configFile)
 
-			l.logger.Debugf("Merging remote config: %s: %s", remote.GitURL, configPath)
+			l.logger.Debugf("Merging remote config: %s: %s", remote.Source(), configPath)
 
 			if ok, err := afero.Exists(l.repo.Fs, configPath); !ok || err != nil {
 				continue
@@ -313,12 +362,14 @@ func (l *Loader) loadRemotes(k *koanf.Koanf, remotes []*Remote) error {
 			if err := k.Load(kfs.Provider(newIOFS(l.repo.Fs), configPath), parser, mergeJobsOption); err != nil {
 				return err
 			}
+			l.tracer.trace(k, configPath)
 
 			extends := k.Strings("extends")
-			if err := extend(k, l.repo.Fs, filepath.Dir(configPath), extends); err != nil {
+			if err := l.extend(k, filepath.Dir(configPath), extends); err != nil {
 				return err
 			}
 		}
+		l.tracer.setRemote("", "")
 
 		// Reset extends to omit issues when extending with remote extends.
 		if err := k.Set("extends", []string(nil)); err != nil {
@@ -330,19 +381,19 @@ func (l *Loader) loadRemotes(k *koanf.Koanf, remotes []*Remote) error {
 }
 
 // extend merges all files listed in 'extends' option into the config.
-func extend(k *koanf.Koanf, filesystem afero.Fs, root string, extends []string) error {
-	return extendRecursive(k, filesystem, root, extends, make(map[string]struct{}))
+func (l *Loader) extend(k *koanf.Koanf, root string, extends []string) error {
+	return l.extendRecursive(k, root, extends, make(map[string]struct{}))
 }
 
 // extendRecursive merges extends.
 // If extends contain other extends they get merged too.
-func extendRecursive(k *koanf.Koanf, filesystem afero.Fs, root string, extends []string, visited map[string]struct{}) error {
+func (l *Loader) extendRecursive(k *koanf.Koanf, root string, extends []string, visited map[string]struct{}) error {
 	for _, pathOrGlob := range extends {
 		if !filepath.IsAbs(pathOrGlob) {
 			pathOrGlob = filepath.Join(root, pathOrGlob)
 		}
 
-		paths, err := afero.Glob(filesystem, pathOrGlob)
+		paths, err := afero.Glob(l.repo.Fs, pathOrGlob)
 		if err != nil {
 			return fmt.Errorf("bad glob syntax for '%s': %w", pathOrGlob, err)
 		}
@@ -358,17 +409,18 @@ func extendRecursive(k *koanf.Koanf, filesystem afero.Fs, root string, extends [
 			if !ok {
 				return fmt.Errorf("can't parse config '%[1]s', file has unsupported or no extension\nhint: rename %[1]s to %[1]s.yml", path)
 			}
-			if err := extent.Load(kfs.Provider(newIOFS(filesystem), path), parser, mergeJobsOption); err != nil {
+			if err := extent.Load(kfs.Provider(newIOFS(l.repo.Fs), path), parser, mergeJobsOption); err != nil {
 				return err
 			}
 
-			if err := extendRecursive(extent, filesystem, root, extent.Strings("extends"), visited); err != nil {
+			if err := l.extendRecursive(extent, root, extent.Strings("extends"), visited); err != nil {
 				return err
 			}
 
 			if err := k.Load(koanfProvider{extent}, nil, mergeJobsOption); err != nil {
 				return err
 			}
+			l.tracer.trace(k, path)
 		}
 	}
 
@@ -484,6 +536,13 @@ func addHook(name string, main, secondary *koanf.Koanf, c *Config) error {
 	if err := mainHook.Load(koanfProvider{overrideHook}, nil, options); err != nil {
 		return err
 	}
+
+	if jobs, ok := mainHook.Get("jobs").([]any); ok {
+		if err := mainHook.Set("jobs", expandMatrix(jobs)); err != nil {
+			return err
+		}
+	}
+
 	var hook Hook
 	if err := mainHook.Unmarshal("", &hook); err != nil {
 		return err
@@ -613,6 +672,10 @@ func mergeJobsSlice(src, dest []any) []any {
 	mergeable := make(map[string]map[string]any)
 	result := make([]any, 0, len(dest))
 
+	// Jobs expanded from a matrix can be overwritten separately.
+	matrixJobs := make(map[string]int)
+	matrixOverrides := make(map[int][]map[string]any)
+
 	// Pass 1: index dest jobs by name and preserve order.
 	for _, maybeJob := range dest {
 		switch destJob := maybeJob.(type) {
@@ -623,6 +686,14 @@ func mergeJobsSlice(src, dest []any) []any {
 			default:
 			}
 
+			for _, expandedJob := range expandMatrixJob(destJob) {
+				switch name := expandedJob["name"].(type) {
+				case string:
+					matrixJobs[name] = len(result)
+				default:
+				}
+			}
+
 			result = append(result, maybeJob)
 		default:
 		}
@@ -634,59 +705,13 @@ func mergeJobsSlice(src, dest []any) []any {
 		case map[string]any:
 			switch name := srcJob["name"].(type) {
 			case string:
-				destJob, ok := mergeable[name]
-				if ok {
-					var srcSubJobs []any
-					var destSubJobs []any
-
-					switch srcGroup := srcJob["group"].(type) {
-					case map[string]any:
-						switch subJobs := srcGroup["jobs"].(type) {
-						case []any:
-							srcSubJobs = subJobs
-						default:
-						}
-					default:
-					}
-					switch destGroup := destJob["group"].(type) {
-					case map[string]any:
-						switch subJobs := destGroup["jobs"].(type) {
-						case []any:
-							destSubJobs = subJobs
-						default:
-						}
-					default:
-					}
-
-					if len(destSubJobs) != 0 && len(srcSubJobs) != 0 {
-						destSubJobs = mergeJobsSlice(srcSubJobs, destSubJobs)
-					}
-
-					// Replace possible {cmd} before merging the jobs
-					switch srcRun := srcJob["run"].(type) {
-					case string:
-						switch destRun := destJob["run"].(type) {
-						case string:
-							newRun := strings.ReplaceAll(srcRun, CMD, destRun)
-							srcJob["run"] = newRun
-						default:
-						}
-					default:
-					}
-
-					maps.Merge(srcJob, destJob)
+				if destJob, ok := mergeable[name]; ok {
+					mergeJob(srcJob, destJob)
+					continue
+				}
 
-					if len(destSubJobs) != 0 {
-						switch destGroup := destJob["group"].(type) {
-						case map[string]any:
-							switch destGroup["jobs"].(type) {
-							case []any:
-								destGroup["jobs"] = destSubJobs
-							default:
-							}
-						default:
-						}
-					}
+				if i, ok := matrixJobs[name]; ok {
+					matrixOverrides[i] = append(matrixOverrides[i], srcJob)
 					continue
 				}
 			default:
@@ -697,5 +722,88 @@ func mergeJobsSlice(src, dest []any) []any {
 		}
 	}
 
-	return result
+	if len(matrixOverrides) == 0 {
+		return result
+	}
+
+	// Pass 3: expand matrix jobs with overwritten expansions.
+	expanded := make([]any, 0, len(result))
+	for i, maybeJob := range result {
+		overrides, ok := matrixOverrides[i]
+		if !ok {
+			expanded = append(expanded, maybeJob)
+			continue
+		}
+
+		expandedJobs := make(map[string]map[string]any)
+		for _, expandedJob := range expandMatrixJob(maybeJob.(map[string]any)) {
+			expandedJobs[expandedJob["name"].(string)] = expandedJob
+			expanded = append(expanded, expandedJob)
+		}
+
+		for _, srcJob := range overrides {
+			if destJob, ok := expandedJobs[srcJob["name"].(string)]; ok {
+				mergeJob(srcJob, destJob)
+			} else {
+				expanded = append(expanded, srcJob)
+			}
+		}
+	}
+
+	return expanded
+}
+
+// mergeJob merges src job settings into dest job.
+func mergeJob(srcJob, destJob map[string]any) {
+	var srcSubJobs []any
+	var destSubJobs []any
+
+	switch srcGroup := srcJob["group"].(type) {
+	case map[string]any:
+		switch subJobs := srcGroup["jobs"].(type) {
+		case []any:
+			srcSubJobs = subJobs
+		default:
+		}
+	default:
+	}
+	switch destGroup := destJob["group"].(type) {
+	case map[string]any:
+		switch subJobs := destGroup["jobs"].(type) {
+		case []any:
+			destSubJobs = subJobs
+		default:
+		}
+	default:
+	}
+
+	if len(destSubJobs) != 0 && len(srcSubJobs) != 0 {
+		destSubJobs = mergeJobsSlice(srcSubJobs, destSubJobs)
+	}
+
+	// Replace possible {cmd} before merging the jobs
+	switch srcRun := srcJob["run"].(type) {
+	case string:
+		switch destRun := destJob["run"].(type) {
+		case string:
+			newRun := strings.ReplaceAll(srcRun, CMD, destRun)
+			srcJob["run"] = newRun
+		default:
+		}
+	default:
+	}
+
+	maps.Merge(srcJob, destJob)
+
+	if len(destSubJobs) != 0 {
+		switch destGroup := destJob["group"].(type) {
+		case map[string]any:
+			switch destGroup["jobs"].(type) {
+			case []any:
+				destGroup["jobs"] = destSubJobs
+			default:
+			}
+		default:
+		}
+	}
 }
@@ -865,6 +865,117 @@ pre-commit:
 				},
 			},
 		},
+		"with matrix jobs": {
+			files: map[string]string{
+				"lefthook.yml": `
+pre-commit:
+  jobs:
+    - name: lint
+      run: yarn lint {matrix.pkg}
+      root: packages/{matrix.pkg}
+      glob: "packages/{matrix.pkg}/*.js"
+      env:
+        PACKAGE: "{matrix.pkg}"
+      matrix:
+        pkg: [api, web]
+    - name: test {matrix.os}-{matrix.pkg}
+      run: test
+      matrix:
+        pkg: [api]
+        os: [linux, macos]
+`,
+			},
+			result: &Config{
+				SourceDir:      ".lefthook",
+				SourceDirLocal: ".lefthook-local",
+				Hooks: map[string]*Hook{
+					"pre-commit": {
+						Name: "pre-commit",
+						Jobs: []*Job{
+							{
+								Name: "lint (api)",
+								Run:  "yarn lint api",
+								Root: "packages/api",
+								Glob: []string{"packages/api/*.js"},
+								Env:  map[string]string{"PACKAGE": "api"},
+							},
+							{
+								Name: "lint (web)",
+								Run:  "yarn lint web",
+								Root: "packages/web",
+								Glob: []string{"packages/web/*.js"},
+								Env:  map[string]string{"PACKAGE": "web"},
+							},
+							{
+								Name: "test linux-api",
+								Run:  "test",
+							},
+							{
+								Name: "test macos-api",
+								Run:  "test",
+							},
+						},
+					},
+				},
+			},
+		},
+		"with matrix jobs overwrite": {
+			files: map[string]string{
+				"lefthook.yml": `
+pre-commit:
+  jobs:
+    - name: lint
+      run: yarn lint {matrix.pkg}
+      matrix:
+        pkg: [api, web, worker]
+    - name: test
+      run: yarn test {matrix.pkg}
+      matrix:
+        pkg: [api, web]
+`,
+				"lefthook-local.yml": `
+pre-commit:
+  jobs:
+    - name: lint (web)
+      run: wrap {cmd}
+      skip: true
+    - name: test
+      run: docker run {cmd}
+`,
+			},
+			result: &Config{
+				SourceDir:      ".lefthook",
+				SourceDirLocal: ".lefthook-local",
+				Hooks: map[string]*Hook{
+					"pre-commit": {
+						Name: "pre-commit",
+						Jobs: []*Job{
+							{
+								Name: "lint (api)",
+								Run:  "yarn lint api",
+							},
+							{
+								Name: "lint (web)",
+								Run:  "wrap yarn lint web",
+								Skip: true,
+							},
+							{
+								Name: "lint (worker)",
+								Run:  "yarn lint worker",
+							},
+							{
+								Name: "test (api)",
+								Run:  "docker run yarn test api",
+							},
+							{
+								Name: "test (web)",
+								Run:  "docker run yarn test web",
+							},
+						},
+					},
+				},
+			},
+		},
 		"with .config/lefthook.yml": {
 			files: map[string]string{
 				filepath.Join(".config", "lefthook.yml"): `
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/git"
+	"github.com/evilmartians/lefthook/v2/internal/version"
+)
+
+const (
+	LockFileName = "lefthook.lock"
+
+	LockMismatchError = "error"
+	LockMismatchWarn  = "warn"
+
+	lockFileMode = 0o644
+	lockHeader   = "# Generated by lefthook install. Commit this file to pin the remotes.\n"
+	hashPrefix   = "sha256:"
+)
+
+// Lock pins the remotes to the fetched commits and contents.
+type Lock struct {
+	Remotes []*LockedRemote `yaml:"remotes,omitempty"`
+}
+
+type LockedRemote struct {
+	GitURL     string `yaml:"git_url,omitempty"`
+	ArchiveURL string `yaml:"archive_url,omitempty"`
+	Ref        string `yaml:"ref,omitempty"`
+	Tag        string `yaml:"tag,omitempty"`
+	Commit     string `yaml:"commit"`
+	Hash       string `yaml:"hash"`
+}
+
+// ReadLock reads the lock file from the root. Returns nil if it doesn't exist.
+func ReadLock(filesystem afero.Fs, root string) (*Lock, error) {
+	data, err := afero.ReadFile(filesystem, filepath.Join(root, LockFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lock Lock
+	if err = yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("%s: %w", LockFileName, err)
+	}
+
+	return &lock, nil
+}
+
+// Write writes the lock file to the root.
+func (lock *Lock) Write(filesystem afero.Fs, root string) error {
+	buf := bytes.NewBufferString(lockHeader)
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(yamlIndent)
+	if err := errors.Join(encoder.Encode(lock), encoder.Close()); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(filesystem, filepath.Join(root, LockFileName), buf.Bytes(), lockFileMode)
+}
+
+// Find returns the locked remote with the same URL and ref.
+func (lock *Lock) Find(remote *Remote) *LockedRemote {
+	if lock == nil {
+		return nil
+	}
+
+	for _, locked := range lock.Remotes {
+		if locked.GitURL == remote.GitURL && locked.ArchiveURL == remote.ArchiveURL && locked.Ref == remote.Ref {
+			return locked
+		}
+	}
+
+	return nil
+}
+
+// LockRemote returns the commit and the content hash of the remote checkout.
+// The commit of an archive remote is the SHA256 checksum of the archive.
+func LockRemote(repo *git.Repo, remote *Remote, sourceDir string) (*LockedRemote, error) {
+	remotePath := remote.Folder(repo)
+
+	var commit string
+	var err error
+	if len(remote.ArchiveURL) > 0 {
+		commit, err = repo.ArchiveChecksum(remotePath)
+	} else {
+		commit, err = repo.RemoteCommit(remotePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := remoteHash(repo.Fs, remotePath, remote.Configs, sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := &LockedRemote{
+		GitURL:     remote.GitURL,
+		ArchiveURL: remote.ArchiveURL,
+		Ref:        remote.Ref,
+		Commit:     commit,
+		Hash:       hash,
+	}
+	if version.IsRange(remote.Ref) {
+		locked.Tag = remote.ResolvedRef(repo)
+	}
+
+	return locked, nil
+}
+
+// remoteHash returns SHA256 of the remote configs and the scripts in source dir.
+func remoteHash(filesystem afero.Fs, remotePath string, configs []string, sourceDir string) (string, error) {
+	if len(configs) == 0 {
+		configs = []string{DefaultConfigName}
+	}
+
+	files := slices.Clone(configs)
+	err := afero.Walk(filesystem, filepath.Join(remotePath, sourceDir), func(path string, info fs.FileInfo, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(remotePath, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, file := range files {
+		content, err := afero.ReadFile(filesystem, filepath.Join(remotePath, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(file), len(content))
+		hash.Write(content)
+	}
+
+	return hashPrefix + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifyLock checks the fetched remotes of the config against lefthook.lock.
+func (l *Loader) VerifyLock(cfg *Config) error {
+	lock, err := ReadLock(l.repo.Fs, l.repo.RootPath)
+	if err != nil || lock == nil {
+		return err
+	}
+
+	for _, remote := range cfg.Remotes {
+		if !remote.Configured() || !remote.Fetched() {
+			continue
+		}
+
+		if ok, _ := afero.DirExists(l.repo.Fs, remote.Folder(l.repo)); !ok {
+			continue
+		}
+
+		if err = l.checkLock(lock, remote, cfg.SourceDir, cfg.LockMismatch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLock compares the remote checkout with the locked one.
+func (l *Loader) checkLock(lock *Lock, remote *Remote, sourceDir, mode string) error {
+	locked := lock.Find(remote)
+	if locked == nil {
+		l.logger.Debugf("Remote %s is not locked in %s", remote.Source(), LockFileName)
+		return nil
+	}
+
+	actual, err := LockRemote(l.repo, remote, sourceDir)
+	if err != nil {
+		return fmt.Errorf("couldn't check remote %s: %w", remote.Source(), err)
+	}
+
+	if actual.Commit != locked.Commit {
+		return l.lockMismatch(mode, "remote %s is at %s, but %s pins %s\nhint: run `lefthook install` to check out the pinned commit or `lefthook remotes update` to pin the latest one", remote.Source(), actual.Commit, LockFileName, locked.Commit)
+	}
+
+	if actual.Hash != locked.Hash {
+		return l.lockMismatch(mode, "remote %s content doesn't match the hash in %s\nhint: run `lefthook install` to restore the pinned content", remote.Source(), LockFileName)
+	}
+
+	return nil
+}
+
+func (l *Loader) lockMismatch(mode, format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	if mode == LockMismatchWarn {
+		l.logger.Warn(err.Error())
+		return nil
+	}
+
+	return err
+}
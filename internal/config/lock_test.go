@@ -0,0 +1,132 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+func TestLock(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+
+	lock, err := ReadLock(fs, root)
+	assert.NoError(t, err)
+	assert.Nil(t, lock)
+
+	lock = &Lock{
+		Remotes: []*LockedRemote{
+			{GitURL: "https://github.com/evilmartians/lefthook", Ref: "v1.0.0", Commit: "abc", Hash: "sha256:def"},
+			{GitURL: "https://github.com/evilmartians/configs", Commit: "123", Hash: "sha256:456"},
+		},
+	}
+	assert.NoError(t, lock.Write(fs, root))
+
+	data, err := afero.ReadFile(fs, filepath.Join(root, LockFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, lockHeader+`remotes:
+  - git_url: https://github.com/evilmartians/lefthook
+    ref: v1.0.0
+    commit: abc
+    hash: sha256:def
+  - git_url: https://github.com/evilmartians/configs
+    commit: "123"
+    hash: sha256:456
+`, string(data))
+
+	read, err := ReadLock(fs, root)
+	assert.NoError(t, err)
+	assert.Equal(t, lock, read)
+
+	assert.Equal(t, lock.Remotes[0], read.Find(&Remote{GitURL: "https://github.com/evilmartians/lefthook", Ref: "v1.0.0"}))
+	assert.Nil(t, read.Find(&Remote{GitURL: "https://github.com/evilmartians/lefthook"}))
+}
+
+func TestLoaderLock(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	remotePath := filepath.Join(root, ".git", "info", "lefthook-remotes", "configs")
+	commit := "0123456789abcdef0123456789abcdef01234567"
+
+	for name, tt := range map[string]struct {
+		config, script string
+		commit         string
+		err            string
+	}{
+		"matching": {
+			commit: commit,
+			script: "echo test",
+		},
+		"other commit": {
+			commit: "fedcba9876543210fedcba9876543210fedcba98",
+			script: "echo test",
+			err:    "remote https://github.com/evilmartians/configs is at fedcba9876543210fedcba9876543210fedcba98, but lefthook.lock pins " + commit + "\nhint: run `lefthook install` to check out the pinned commit or `lefthook remotes update` to pin the latest one",
+		},
+		"changed script": {
+			commit: commit,
+			script: "echo changed",
+			err:    "remote https://github.com/evilmartians/configs content doesn't match the hash in lefthook.lock\nhint: run `lefthook install` to restore the pinned content",
+		},
+		"changed script with warning": {
+			config: "lock_mismatch: warn\n",
+			commit: commit,
+			script: "echo changed",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			fs := afero.NewMemMapFs()
+			repo := gittest.NewRepositoryBuilder().Fs(fs).Root(root).Build()
+
+			remote := &Remote{GitURL: "https://github.com/evilmartians/configs"}
+			assert.NoError(afero.WriteFile(fs, filepath.Join(remotePath, ".git", "HEAD"), []byte(commit+"\n"), 0o644))
+			assert.NoError(afero.WriteFile(fs, filepath.Join(remotePath, "lefthook.yml"), []byte("pre-commit:\n  jobs:\n    - script: test.sh\n"), 0o644))
+			assert.NoError(afero.WriteFile(fs, filepath.Join(remotePath, DefaultSourceDir, "pre-commit", "test.sh"), []byte("echo test"), 0o644))
+
+			locked, err := LockRemote(repo, remote, DefaultSourceDir)
+			assert.NoError(err)
+			assert.Equal(commit, locked.Commit)
+			assert.NoError((&Lock{Remotes: []*LockedRemote{locked}}).Write(fs, root))
+
+			assert.NoError(afero.WriteFile(fs, filepath.Join(remotePath, ".git", "HEAD"), []byte(tt.commit+"\n"), 0o644))
+			assert.NoError(afero.WriteFile(fs, filepath.Join(remotePath, DefaultSourceDir, "pre-commit", "test.sh"), []byte(tt.script), 0o644))
+			assert.NoError(afero.WriteFile(fs, filepath.Join(root, "lefthook.yml"), []byte(tt.config+"remotes:\n  - git_url: "+remote.GitURL+"\n"), 0o644))
+
+			_, err = NewLoader(repo, loggertest.New()).Load()
+			if len(tt.err) > 0 {
+				assert.EqualError(err, tt.err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestRemoteResolvedRef(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	repo := gittest.NewRepositoryBuilder().Root(root).Fs(fs).Build()
+	remote := &Remote{GitURL: "https://github.com/evilmartians/configs", Ref: "^1.0"}
+
+	for _, tag := range []string{"v1.1.0", "v1.2.0"} {
+		config := "[remote \"origin\"]\n\turl = " + remote.GitURL + "\n"
+		assert.NoError(t, afero.WriteFile(fs, filepath.Join(repo.RemoteFolder(remote.GitURL, tag), ".git", "config"), []byte(config), 0o644))
+	}
+
+	assert.Equal(t, "v1.2.0", remote.ResolvedRef(repo))
+
+	lock := &Lock{Remotes: []*LockedRemote{{GitURL: remote.GitURL, Ref: remote.Ref, Tag: "v1.1.0", Commit: "abc", Hash: "sha256:def"}}}
+	assert.NoError(t, lock.Write(fs, root))
+	assert.Equal(t, "v1.1.0", remote.ResolvedRef(repo))
+}
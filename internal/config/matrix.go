@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/knadh/koanf/maps"
+)
+
+const matrixTemplatePrefix = "{matrix."
+
+// expandMatrix replaces the jobs having `matrix` with the jobs for each
+// combination of the matrix values. Jobs in groups get expanded too.
+func expandMatrix(jobs []any) []any {
+	result := make([]any, 0, len(jobs))
+
+	for _, maybeJob := range jobs {
+		job, ok := maybeJob.(map[string]any)
+		if !ok {
+			result = append(result, maybeJob)
+			continue
+		}
+
+		if group, ok := job["group"].(map[string]any); ok {
+			if subJobs, ok := group["jobs"].([]any); ok {
+				group["jobs"] = expandMatrix(subJobs)
+			}
+		}
+
+		if _, ok := job["matrix"]; !ok {
+			result = append(result, job)
+			continue
+		}
+
+		for _, expandedJob := range expandMatrixJob(job) {
+			result = append(result, expandedJob)
+		}
+	}
+
+	return result
+}
+
+// expandMatrixJob returns a copy of the job for each combination of the matrix values
+// with `{matrix.<key>}` templates replaced. Returns nil if the job has no matrix.
+func expandMatrixJob(job map[string]any) []map[string]any {
+	matrix, ok := job["matrix"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	keys := make([]string, 0, len(matrix))
+	for key := range matrix {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	combinations := []map[string]string{{}}
+	for _, key := range keys {
+		values, ok := matrix[key].([]any)
+		if !ok {
+			values = []any{matrix[key]}
+		}
+
+		next := make([]map[string]string, 0, len(combinations)*len(values))
+		for _, combination := range combinations {
+			for _, value := range values {
+				expanded := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					expanded[k] = v
+				}
+				expanded[key] = fmt.Sprint(value)
+				next = append(next, expanded)
+			}
+		}
+		combinations = next
+	}
+
+	jobs := make([]map[string]any, 0, len(combinations))
+	for _, combination := range combinations {
+		expandedJob := maps.Copy(job)
+		delete(expandedJob, "matrix")
+
+		replacer := newMatrixReplacer(keys, combination)
+		for _, key := range []string{"run", "root"} {
+			if value, ok := expandedJob[key].(string); ok {
+				expandedJob[key] = replacer.Replace(value)
+			}
+		}
+
+		switch glob := expandedJob["glob"].(type) {
+		case string:
+			expandedJob["glob"] = replacer.Replace(glob)
+		case []any:
+			for i, value := range glob {
+				if pattern, ok := value.(string); ok {
+					glob[i] = replacer.Replace(pattern)
+				}
+			}
+		default:
+		}
+
+		if env, ok := expandedJob["env"].(map[string]any); ok {
+			for name, value := range env {
+				if str, ok := value.(string); ok {
+					env[name] = replacer.Replace(str)
+				}
+			}
+		}
+
+		if name, ok := expandedJob["name"].(string); ok {
+			if strings.Contains(name, matrixTemplatePrefix) {
+				expandedJob["name"] = replacer.Replace(name)
+			} else {
+				values := make([]string, 0, len(keys))
+				for _, key := range keys {
+					values = append(values, combination[key])
+				}
+				expandedJob["name"] = name + " (" + strings.Join(values, ", ") + ")"
+			}
+		}
+
+		jobs = append(jobs, expandedJob)
+	}
+
+	return jobs
+}
+
+func newMatrixReplacer(keys []string, combination map[string]string) *strings.Replacer {
+	oldnew := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		oldnew = append(oldnew, matrixTemplatePrefix+key+"}", combination[key])
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
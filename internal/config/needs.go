@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// JobsDependencies resolves `needs` of the given sibling jobs into indexes.
+//
+// A name of a job with `matrix` refers to all the jobs expanded from it. The
+// result contains the list of job indexes each job depends on. An error is
+// returned if a job needs an unknown job or the dependencies form a cycle.
+func JobsDependencies(jobs []*Job) ([][]int, error) {
+	indexes := make(map[string][]int, len(jobs))
+	expanded := make(map[string][]int)
+	for i, job := range jobs {
+		if len(job.Name) == 0 {
+			continue
+		}
+
+		indexes[job.Name] = append(indexes[job.Name], i)
+		if base, ok := matrixBaseName(job.Name); ok {
+			expanded[base] = append(expanded[base], i)
+		}
+	}
+
+	deps := make([][]int, len(jobs))
+	for i, job := range jobs {
+		for _, name := range job.Needs {
+			idxs, ok := indexes[name]
+			if !ok {
+				idxs, ok = expanded[name]
+			}
+			if !ok {
+				return nil, fmt.Errorf("job %q needs unknown job %q", job.PrintableName(""), name)
+			}
+
+			deps[i] = append(deps[i], idxs...)
+		}
+	}
+
+	if cycle := findCycle(jobs, deps); len(cycle) > 0 {
+		return nil, fmt.Errorf("jobs dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return deps, nil
+}
+
+// JobsOrder returns the job indexes in the order of execution, so that every
+// job follows the jobs it depends on. Otherwise the jobs keep the order they
+// are defined in.
+func JobsOrder(deps [][]int) []int {
+	order := make([]int, 0, len(deps))
+	ordered := make([]bool, len(deps))
+
+	for len(order) < len(deps) {
+		for i, jobDeps := range deps {
+			if ordered[i] || slices.ContainsFunc(jobDeps, func(dep int) bool { return !ordered[dep] }) {
+				continue
+			}
+
+			order = append(order, i)
+			ordered[i] = true
+			break
+		}
+	}
+
+	return order
+}
+
+// ValidateNeeds checks `needs` of the jobs and all nested groups.
+func ValidateNeeds(jobs []*Job) []error {
+	var errs []error
+
+	if _, err := JobsDependencies(jobs); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, job := range jobs {
+		if job.Group != nil {
+			errs = append(errs, ValidateNeeds(job.Group.Jobs)...)
+		}
+	}
+
+	return errs
+}
+
+// matrixBaseName returns the name of the job with `matrix` the given job was
+// expanded from.
+func matrixBaseName(name string) (string, bool) {
+	if !strings.HasSuffix(name, ")") {
+		return "", false
+	}
+
+	idx := strings.LastIndex(name, " (")
+	if idx <= 0 {
+		return "", false
+	}
+
+	return name[:idx], true
+}
+
+const (
+	needsUnvisited = iota
+	needsVisiting
+	needsVisited
+)
+
+// findCycle returns the names of the jobs forming a dependency cycle, if any.
+func findCycle(jobs []*Job, deps [][]int) []string {
+	marks := make([]int, len(jobs))
+	path := make([]int, 0, len(jobs))
+
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		marks[i] = needsVisiting
+		path = append(path, i)
+
+		for _, dep := range deps[i] {
+			switch marks[dep] {
+			case needsVisiting:
+				cycle := make([]string, 0, len(path)+1)
+				for j := len(path) - 1; j >= 0; j-- {
+					cycle = append(cycle, jobs[path[j]].Name)
+					if path[j] == dep {
+						break
+					}
+				}
+				slices.Reverse(cycle)
+
+				return append(cycle, jobs[dep].Name)
+			case needsUnvisited:
+				if cycle := visit(dep); len(cycle) > 0 {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		marks[i] = needsVisited
+
+		return nil
+	}
+
+	for i := range jobs {
+		if marks[i] != needsUnvisited {
+			continue
+		}
+
+		if cycle := visit(i); len(cycle) > 0 {
+			return cycle
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobsDependencies(t *testing.T) {
+	for name, tt := range map[string]struct {
+		jobs []*Job
+		deps [][]int
+		err  string
+	}{
+		"without needs": {
+			jobs: []*Job{{Name: "lint"}, {Name: "test"}},
+			deps: [][]int{nil, nil},
+		},
+		"with needs": {
+			jobs: []*Job{
+				{Name: "test", Needs: []string{"lint", "typecheck"}},
+				{Name: "lint"},
+				{Name: "typecheck"},
+			},
+			deps: [][]int{{1, 2}, nil, nil},
+		},
+		"with needs of matrix jobs": {
+			jobs: []*Job{
+				{Name: "test (1.22)"},
+				{Name: "test (1.23)"},
+				{Name: "report", Needs: []string{"test"}},
+			},
+			deps: [][]int{nil, nil, {0, 1}},
+		},
+		"with needs of one matrix job": {
+			jobs: []*Job{
+				{Name: "test (1.22)"},
+				{Name: "test (1.23)"},
+				{Name: "report", Needs: []string{"test (1.23)"}},
+			},
+			deps: [][]int{nil, nil, {1}},
+		},
+		"with unknown job": {
+			jobs: []*Job{
+				{Name: "test", Needs: []string{"lint"}},
+			},
+			err: `job "test" needs unknown job "lint"`,
+		},
+		"with self dependency": {
+			jobs: []*Job{
+				{Name: "test", Needs: []string{"test"}},
+			},
+			err: "jobs dependency cycle: test -> test",
+		},
+		"with cycle": {
+			jobs: []*Job{
+				{Name: "a", Needs: []string{"b"}},
+				{Name: "b", Needs: []string{"c"}},
+				{Name: "c", Needs: []string{"a"}},
+				{Name: "d", Needs: []string{"a"}},
+			},
+			err: "jobs dependency cycle: a -> b -> c -> a",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			deps, err := JobsDependencies(tt.jobs)
+			if len(tt.err) > 0 {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.deps, deps)
+		})
+	}
+}
+
+func TestJobsOrder(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, JobsOrder([][]int{nil, nil, nil}))
+	assert.Equal(t, []int{1, 2, 0, 3}, JobsOrder([][]int{{1, 2}, nil, nil, nil}))
+	assert.Equal(t, []int{2, 1, 0}, JobsOrder([][]int{{1}, {2}, nil}))
+}
+
+func TestValidateNeeds(t *testing.T) {
+	jobs := []*Job{
+		{Name: "test", Needs: []string{"lint"}},
+		{Name: "lint"},
+		{
+			Name: "group",
+			Group: &Group{
+				Jobs: []*Job{
+					{Name: "build", Needs: []string{"missing"}},
+				},
+			},
+		},
+	}
+
+	errs := ValidateNeeds(jobs)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `job "build" needs unknown job "missing"`)
+}
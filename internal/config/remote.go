@@ -1,9 +1,22 @@
 package config
 
+import (
+	"path/filepath"
+
+	"github.com/evilmartians/lefthook/v2/internal/git"
+	"github.com/evilmartians/lefthook/v2/internal/version"
+)
+
 type Remote struct {
-	GitURL string `json:"git_url,omitempty" jsonschema:"description=A URL to Git repository. It will be accessed with privileges of the machine lefthook runs on." koanf:"git_url" mapstructure:"git_url" toml:"git_url" yaml:"git_url"`
+	GitURL string `json:"git_url,omitempty" jsonschema:"description=A URL to Git repository. It will be accessed with privileges of the machine lefthook runs on." koanf:"git_url" mapstructure:"git_url,omitempty" toml:"git_url,omitempty" yaml:"git_url,omitempty"`
+
+	ArchiveURL string `json:"archive_url,omitempty" jsonschema:"description=A URL to tar.gz or zip archive with the remote configs" koanf:"archive_url" mapstructure:"archive_url,omitempty" toml:"archive_url,omitempty" yaml:"archive_url,omitempty"`
 
-	Ref string `json:"ref,omitempty" jsonschema:"description=An optional *branch* or *tag* name" mapstructure:"ref,omitempty" toml:"ref,omitempty" yaml:",omitempty"`
+	SHA256 string `json:"sha256,omitempty" jsonschema:"description=An optional SHA256 checksum of the archive" mapstructure:"sha256,omitempty" toml:"sha256,omitempty" yaml:",omitempty"`
+
+	Path string `json:"path,omitempty" jsonschema:"description=A path to a local directory with the remote configs. Relative paths are resolved from the repository root." mapstructure:"path,omitempty" toml:"path,omitempty" yaml:",omitempty"`
+
+	Ref string `json:"ref,omitempty" jsonschema:"description=An optional *branch* or *tag* name or a version range like ^1.2 matching the tags" mapstructure:"ref,omitempty" toml:"ref,omitempty" yaml:",omitempty"`
 
 	Configs []string `json:"configs,omitempty" jsonschema:"description=An optional array of config paths from remote's root,default=lefthook.yml" mapstructure:"configs,omitempty" toml:"configs,omitempty" yaml:",omitempty"`
 
@@ -17,5 +30,59 @@ func (r *Remote) Configured() bool {
 		return false
 	}
 
-	return len(r.GitURL) > 0
+	return len(r.GitURL) > 0 || len(r.ArchiveURL) > 0 || len(r.Path) > 0
+}
+
+// Source returns the URL or the path the remote is loaded from.
+func (r *Remote) Source() string {
+	switch {
+	case len(r.GitURL) > 0:
+		return r.GitURL
+	case len(r.ArchiveURL) > 0:
+		return r.ArchiveURL
+	default:
+		return r.Path
+	}
+}
+
+// Fetched tells whether the remote is downloaded into the remotes folder.
+// Path remotes are used in place.
+func (r *Remote) Fetched() bool {
+	return len(r.GitURL) > 0 || len(r.ArchiveURL) > 0
+}
+
+// Folder returns the directory containing the remote configs.
+func (r *Remote) Folder(repo *git.Repo) string {
+	switch {
+	case len(r.GitURL) > 0:
+		return repo.RemoteFolder(r.GitURL, r.ResolvedRef(repo))
+	case len(r.ArchiveURL) > 0:
+		return repo.ArchiveFolder(r.ArchiveURL)
+	case filepath.IsAbs(r.Path):
+		return r.Path
+	default:
+		return filepath.Join(repo.RootPath, r.Path)
+	}
+}
+
+// ResolvedRef returns the ref the remote is fetched at. A version range is
+// resolved to the tag pinned in lefthook.lock, or to the highest matching tag
+// among the fetched ones if the remote is not locked.
+func (r *Remote) ResolvedRef(repo *git.Repo) string {
+	if !version.IsRange(r.Ref) {
+		return r.Ref
+	}
+
+	if lock, err := ReadLock(repo.Fs, repo.RootPath); err == nil {
+		if locked := lock.Find(r); locked != nil && len(locked.Tag) > 0 {
+			return locked.Tag
+		}
+	}
+
+	tag, err := version.MaxSatisfying(r.Ref, repo.CachedRemoteRefs(r.GitURL))
+	if err != nil {
+		return r.Ref
+	}
+
+	return tag
 }
@@ -0,0 +1,14 @@
+package config
+
+// Report holds the paths of the files the results of a hook run are written to.
+// `{hook}` in a path is replaced with the name of the hook.
+//
+// Example lefthook.yml:
+//
+//	report:
+//	  junit: tmp/lefthook-{hook}.xml
+//	  json: tmp/lefthook-{hook}.json
+type Report struct {
+	JSON  string `json:"json,omitempty"  jsonschema:"description=Path to the JSON report file"      mapstructure:"json,omitempty"  toml:"json,omitempty"  yaml:"json,omitempty"`
+	JUnit string `json:"junit,omitempty" jsonschema:"description=Path to the JUnit XML report file" mapstructure:"junit,omitempty" toml:"junit,omitempty" yaml:"junit,omitempty"`
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"slices"
+
 	"github.com/gobwas/glob"
 
 	"github.com/evilmartians/lefthook/v2/internal/git"
@@ -66,6 +68,10 @@ func (sc *SkipChecker) matchesSlices(gitState func() git.State, slice []any) boo
 				return true
 			}
 
+			if sc.matchesPushingTags(gitState, typedState) {
+				return true
+			}
+
 			if sc.matchesCommands(typedState) {
 				return true
 			}
@@ -91,6 +97,15 @@ func (sc *SkipChecker) matchesRef(state func() git.State, typedState map[string]
 	return g.Match(branch)
 }
 
+func (sc *SkipChecker) matchesPushingTags(state func() git.State, typedState map[string]any) bool {
+	pushingTags, ok := typedState["pushing_tags"].(bool)
+	if !ok {
+		return false
+	}
+
+	return pushingTags == slices.ContainsFunc(state().PushRefs, git.PushRef.IsTag)
+}
+
 func (sc *SkipChecker) matchesCommands(typedState map[string]any) bool {
 	commandLine, ok := typedState["run"].(string)
 	if !ok {
@@ -155,6 +155,30 @@ func TestSkipChecker_Check(t *testing.T) {
 			only:    []any{map[string]any{"run": "fail"}},
 			skipped: true,
 		},
+		{
+			name: "when only pushing tags",
+			state: func() git.State {
+				return git.State{PushRefs: []git.PushRef{{LocalRef: "refs/tags/v1.0", RemoteRef: "refs/tags/v1.0"}}}
+			},
+			only:    []any{map[string]any{"pushing_tags": true}},
+			skipped: false,
+		},
+		{
+			name: "when only pushing tags with branches",
+			state: func() git.State {
+				return git.State{PushRefs: []git.PushRef{{LocalRef: "refs/heads/main", RemoteRef: "refs/heads/main"}}}
+			},
+			only:    []any{map[string]any{"pushing_tags": true}},
+			skipped: true,
+		},
+		{
+			name: "when skip not pushing tags",
+			state: func() git.State {
+				return git.State{PushRefs: []git.PushRef{{LocalRef: "refs/heads/main", RemoteRef: "refs/heads/main"}}}
+			},
+			skip:    []any{map[string]any{"pushing_tags": false}},
+			skipped: true,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			if skipChecker.Check(tt.state, tt.skip, tt.only) != tt.skipped {
@@ -0,0 +1,124 @@
+// Package expr implements the expressions of `if` option.
+//
+// Expressions support string literals, `true` and `false`, variables
+// (`branch`, `state`, `hook`, `args`, `env.NAME`), functions
+// (`exists(path)`, `files.changed(glob)`), comparison operators (`==`, `!=`),
+// glob matching operators (`=~`, `!~`), logical operators (`&&`, `||`, `!`),
+// and parentheses. Non-empty strings are considered true.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/filter"
+)
+
+var errUnterminatedString = errors.New("unterminated string")
+
+// Context contains the values available in the expressions.
+type Context struct {
+	Branch string
+	State  string
+	Hook   string
+	Args   []string
+
+	// GlobMatcher is the glob matching engine used for the files of the jobs.
+	GlobMatcher string
+
+	// Env returns the value of the ENV variable.
+	Env func(name string) string
+	// Files returns the files matched by the job or used by the hook.
+	Files func() []string
+	// Exists checks if the file exists in the repository.
+	Exists func(path string) bool
+}
+
+// Expression is a parsed expression ready for evaluation.
+type Expression struct {
+	root node
+}
+
+var variables = map[string]func(ctx *Context) string{
+	"branch": func(ctx *Context) string { return ctx.Branch },
+	"state":  func(ctx *Context) string { return ctx.State },
+	"hook":   func(ctx *Context) string { return ctx.Hook },
+	"args":   func(ctx *Context) string { return strings.Join(ctx.Args, " ") },
+}
+
+var functions = map[string]func(ctx *Context, arg string) (bool, error){
+	"exists": func(ctx *Context, path string) (bool, error) {
+		return ctx.Exists != nil && ctx.Exists(path), nil
+	},
+	"files.changed": func(ctx *Context, pattern string) (bool, error) {
+		if ctx.Files == nil {
+			return false, nil
+		}
+
+		match, err := filter.Compile(pattern, ctx.GlobMatcher)
+		if err != nil {
+			return false, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+
+		for _, file := range ctx.Files() {
+			if match(file) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	},
+}
+
+// Parse parses the expression and checks it for unknown variables,
+// unknown functions, type errors, and invalid globs for the glob matcher.
+func Parse(input string, globMatcher string) (*Expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, globMatcher: globMatcher}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos+1)
+	}
+
+	return &Expression{root: root}, nil
+}
+
+// Eval returns the result of the expression.
+func (e *Expression) Eval(ctx *Context) (bool, error) {
+	value, err := e.root.eval(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return truthy(value), nil
+}
+
+// Eval parses and evaluates the expression.
+func Eval(input string, ctx *Context) (bool, error) {
+	expression, err := Parse(input, ctx.GlobMatcher)
+	if err != nil {
+		return false, err
+	}
+
+	return expression.Eval(ctx)
+}
+
+func truthy(value any) bool {
+	switch typedValue := value.(type) {
+	case bool:
+		return typedValue
+	case string:
+		return len(typedValue) > 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEval(t *testing.T) {
+	ctx := &Context{
+		Branch: "release/1.2",
+		State:  "rebase",
+		Hook:   "pre-commit",
+		Args:   []string{"origin", "git@github.com:evilmartians/lefthook.git"},
+		Env: func(name string) string {
+			return map[string]string{"CI": "true", "EMPTY": "", "ÜBER": "yes"}[name]
+		},
+		Files: func() []string {
+			return []string{"go.mod", "internal/expr/expr.go"}
+		},
+		Exists: func(path string) bool {
+			return path == "Gemfile"
+		},
+	}
+
+	for expression, result := range map[string]bool{
+		`true`:                    true,
+		`false`:                   false,
+		`"string"`:                true,
+		`''`:                      false,
+		`env.CI`:                  true,
+		`env.EMPTY`:               false,
+		`env.MISSING`:             false,
+		`env.CI == "true"`:        true,
+		`env.CI != 'true'`:        false,
+		`branch =~ "release/*"`:   true,
+		`branch !~ "release/*"`:   false,
+		`branch == "main"`:        false,
+		`state == "rebase"`:       true,
+		`hook == "pre-commit"`:    true,
+		`args =~ "origin *"`:      true,
+		`exists("Gemfile")`:       true,
+		`exists("package.json")`:  false,
+		`files.changed("go.mod")`: true,
+		`files.changed("*.go")`:   true,
+		`files.changed("*.rb")`:   false,
+		`!files.changed("*.rb")`:  true,
+		`!!env.CI`:                true,
+		`true == false`:           false,
+		`env.CI == "true" && branch =~ "release/*" && files.changed("go.mod")`: true,
+		`env.CI == "true" && branch == "main"`:                                 false,
+		`branch == "main" || state == "rebase"`:                                true,
+		`false && false || true`:                                               true,
+		`false && (false || true)`:                                             false,
+		`"a\"b" == 'a"b'`:                                                      true,
+		"env.ÜBER ==\u00a0'yes'":                                               true,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			value, err := Eval(expression, ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, result, value)
+		})
+	}
+}
+
+func TestEvalGlobMatcher(t *testing.T) {
+	files := func() []string { return []string{"internal/expr/expr.go"} }
+
+	for name, tt := range map[string]struct {
+		globMatcher string
+		expressions map[string]bool
+	}{
+		"gobwas": {
+			expressions: map[string]bool{
+				`files.changed("internal/*.go")`:    true,
+				`files.changed("INTERNAL/**/*.GO")`: true,
+				`branch =~ "RELEASE/*"`:             true,
+			},
+		},
+		"doublestar": {
+			globMatcher: "doublestar",
+			expressions: map[string]bool{
+				`files.changed("internal/*.go")`:    false,
+				`files.changed("INTERNAL/**/*.GO")`: true,
+				`branch =~ "RELEASE/*"`:             true,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := &Context{Branch: "release/1.2", GlobMatcher: tt.globMatcher, Files: files}
+
+			for expression, result := range tt.expressions {
+				value, err := Eval(expression, ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, result, value, expression)
+			}
+		})
+	}
+}
+
+func TestEvalEmptyContext(t *testing.T) {
+	value, err := Eval(`env.CI || exists("go.mod") || files.changed("*")`, &Context{})
+	assert.NoError(t, err)
+	assert.False(t, value)
+}
+
+func TestParseErrors(t *testing.T) {
+	for expression, err := range map[string]string{
+		``:                         "unexpected end of expression at position 1",
+		`branch ==`:                "unexpected end of expression at position 10",
+		`branch = "main"`:          `unexpected character '=' at position 8`,
+		`"main`:                    "unterminated string at position 1",
+		`(true`:                    `expected ")", got end of expression at position 6`,
+		`true false`:               `unexpected "false" at position 6`,
+		`commit == "x"`:            `unknown variable "commit" at position 1`,
+		`env == "x"`:               `unknown variable "env" at position 1`,
+		`run("make")`:              `unknown function "run" at position 1`,
+		`env.CI == true`:           "can't compare string with boolean at position 8",
+		`exists(true)`:             "exists expects a string at position 1",
+		`branch =~ true`:           "=~ expects strings at position 8",
+		`branch =~ "[release"`:     `invalid glob "[release": unexpected end of input`,
+		`files.changed("[a")`:      `invalid glob "[a": unexpected end of input`,
+		`files.changed("a", "b")`:  `expected ")", got "," at position 18`,
+		`env.`:                     "unexpected end of expression at position 5",
+		`branch == "main" && || x`: `unexpected "||" at position 21`,
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, parseErr := Parse(expression, "")
+			assert.EqualError(t, parseErr, err)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.value)
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// operators are sorted so that the longer operators are matched first.
+var operators = []string{"==", "!=", "=~", "!~", "&&", "||", "!", "(", ")", ",", "."}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(input); {
+		char, size := utf8.DecodeRuneInString(input[pos:])
+
+		switch {
+		case unicode.IsSpace(char):
+			pos += size
+		case char == '"' || char == '\'':
+			value, length, err := readString(input[pos:])
+			if err != nil {
+				return nil, fmt.Errorf("%w at position %d", err, pos+1)
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: pos})
+			pos += length
+		case isIdentChar(char):
+			start := pos
+			for pos < len(input) {
+				char, size := utf8.DecodeRuneInString(input[pos:])
+				if !isIdentChar(char) {
+					break
+				}
+
+				pos += size
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, value: input[start:pos], pos: start})
+		default:
+			operator := ""
+			for _, op := range operators {
+				if strings.HasPrefix(input[pos:], op) {
+					operator = op
+					break
+				}
+			}
+			if len(operator) == 0 {
+				return nil, fmt.Errorf("unexpected character %q at position %d", char, pos+1)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, value: operator, pos: pos})
+			pos += len(operator)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+// readString reads a quoted string and returns its value and the length including quotes.
+func readString(input string) (string, int, error) {
+	quote := input[0]
+
+	var value strings.Builder
+	for i := 1; i < len(input); i++ {
+		switch input[i] {
+		case quote:
+			return value.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(input) {
+				i++
+			}
+		}
+
+		value.WriteByte(input[i])
+	}
+
+	return "", 0, errUnterminatedString
+}
+
+func isIdentChar(char rune) bool {
+	return char == '_' || char == '-' || unicode.IsLetter(char) || unicode.IsDigit(char)
+}
@@ -0,0 +1,165 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/filter"
+)
+
+type literalNode struct {
+	value any
+}
+
+func (n *literalNode) valueType() valueType {
+	if _, ok := n.value.(bool); ok {
+		return typeBool
+	}
+
+	return typeString
+}
+
+func (n *literalNode) eval(_ *Context) (any, error) {
+	return n.value, nil
+}
+
+type variableNode struct {
+	get func(ctx *Context) string
+}
+
+func (n *variableNode) valueType() valueType {
+	return typeString
+}
+
+func (n *variableNode) eval(ctx *Context) (any, error) {
+	return n.get(ctx), nil
+}
+
+type envNode struct {
+	name string
+}
+
+func (n *envNode) valueType() valueType {
+	return typeString
+}
+
+func (n *envNode) eval(ctx *Context) (any, error) {
+	if ctx.Env == nil {
+		return "", nil
+	}
+
+	return ctx.Env(n.name), nil
+}
+
+type callNode struct {
+	fn  func(ctx *Context, arg string) (bool, error)
+	arg node
+}
+
+func (n *callNode) valueType() valueType {
+	return typeBool
+}
+
+func (n *callNode) eval(ctx *Context) (any, error) {
+	arg, err := n.arg.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.fn(ctx, arg.(string))
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) valueType() valueType {
+	return typeBool
+}
+
+func (n *notNode) eval(ctx *Context) (any, error) {
+	value, err := n.operand.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return !truthy(value), nil
+}
+
+type logicalNode struct {
+	or          bool
+	left, right node
+}
+
+func (n *logicalNode) valueType() valueType {
+	return typeBool
+}
+
+func (n *logicalNode) eval(ctx *Context) (any, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit evaluation
+	if truthy(left) == n.or {
+		return n.or, nil
+	}
+
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return truthy(right), nil
+}
+
+type equalNode struct {
+	negate      bool
+	left, right node
+}
+
+func (n *equalNode) valueType() valueType {
+	return typeBool
+}
+
+func (n *equalNode) eval(ctx *Context) (any, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return (left == right) != n.negate, nil
+}
+
+type matchNode struct {
+	negate      bool
+	left, right node
+}
+
+func (n *matchNode) valueType() valueType {
+	return typeBool
+}
+
+func (n *matchNode) eval(ctx *Context) (any, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := filter.Compile(right.(string), ctx.GlobMatcher)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", right, err)
+	}
+
+	return match(left.(string)) != n.negate, nil
+}
@@ -0,0 +1,258 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/filter"
+)
+
+type valueType int
+
+const (
+	typeString valueType = iota
+	typeBool
+)
+
+func (t valueType) String() string {
+	if t == typeBool {
+		return "boolean"
+	}
+
+	return "string"
+}
+
+type node interface {
+	valueType() valueType
+	eval(ctx *Context) (any, error)
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	globMatcher string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+// accept consumes the next token if it is the given operator.
+func (p *parser) accept(operator string) bool {
+	if tok := p.peek(); tok.kind == tokenOperator && tok.value == operator {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(operator string) error {
+	if tok := p.peek(); !p.accept(operator) {
+		return fmt.Errorf("expected %q, got %s at position %d", operator, tok, tok.pos+1)
+	}
+
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalNode{or: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return left, nil
+	}
+
+	switch tok.value {
+	case "==", "!=":
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		if left.valueType() != right.valueType() {
+			return nil, fmt.Errorf(
+				"can't compare %s with %s at position %d", left.valueType(), right.valueType(), tok.pos+1,
+			)
+		}
+
+		return &equalNode{negate: tok.value == "!=", left: left, right: right}, nil
+	case "=~", "!~":
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		if left.valueType() != typeString || right.valueType() != typeString {
+			return nil, fmt.Errorf("%s expects strings at position %d", tok.value, tok.pos+1)
+		}
+
+		if err = p.checkGlob(right); err != nil {
+			return nil, err
+		}
+
+		return &matchNode{negate: tok.value == "!~", left: left, right: right}, nil
+	default:
+		return left, nil
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenString:
+		return &literalNode{value: tok.value}, nil
+	case tokenIdent:
+		return p.parseIdent(tok)
+	case tokenOperator:
+		if tok.value == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+
+			return inner, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected %s at position %d", tok, tok.pos+1)
+}
+
+func (p *parser) parseIdent(tok token) (node, error) {
+	switch tok.value {
+	case "true":
+		return &literalNode{value: true}, nil
+	case "false":
+		return &literalNode{value: false}, nil
+	}
+
+	path := []string{tok.value}
+	for p.accept(".") {
+		part := p.next()
+		if part.kind != tokenIdent {
+			return nil, fmt.Errorf("unexpected %s at position %d", part, part.pos+1)
+		}
+
+		path = append(path, part.value)
+	}
+	name := strings.Join(path, ".")
+
+	if p.accept("(") {
+		fn, ok := functions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %q at position %d", name, tok.pos+1)
+		}
+
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if arg.valueType() != typeString {
+			return nil, fmt.Errorf("%s expects a string at position %d", name, tok.pos+1)
+		}
+		if name == "files.changed" {
+			if err = p.checkGlob(arg); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return &callNode{fn: fn, arg: arg}, nil
+	}
+
+	if len(path) == 2 && path[0] == "env" {
+		return &envNode{name: path[1]}, nil
+	}
+
+	get, ok := variables[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %q at position %d", name, tok.pos+1)
+	}
+
+	return &variableNode{get: get}, nil
+}
+
+// checkGlob checks the glob pattern if it is known before the evaluation.
+func (p *parser) checkGlob(pattern node) error {
+	literal, ok := pattern.(*literalNode)
+	if !ok {
+		return nil
+	}
+
+	if _, err := filter.Compile(literal.value.(string), p.globMatcher); err != nil {
+		return fmt.Errorf("invalid glob %q: %w", literal.value, err)
+	}
+
+	return nil
+}
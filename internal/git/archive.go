@@ -0,0 +1,341 @@
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// ArchiveMarker is the file in the extracted archive folder storing the
+	// SHA256 checksum of the archive. Its modification time is the fetch time.
+	ArchiveMarker = ".lefthook-archive"
+
+	archiveTimeout  = 60 * time.Second
+	archiveMaxSize  = 100 << 20
+	archiveDirMode  = 0o755
+	archiveFileMode = 0o644
+	archiveExecMode = 0o755
+
+	// archiveMaxExtractedSize limits the total size of the extracted files.
+	archiveMaxExtractedSize = 500 << 20
+
+	// archiveHashLength is the length of the URL hash distinguishing the
+	// archives with the same file name.
+	archiveHashLength = 8
+)
+
+var (
+	errArchiveTooLarge = errors.New("extracted files are larger than 500 MB")
+
+	archiveExtensions = []string{".tar.gz", ".tgz", ".zip"}
+	gzipMagic         = []byte{0x1f, 0x8b}
+	zipMagic          = []byte("PK\x03\x04")
+)
+
+type archiveFile struct {
+	name string
+	dir  bool
+	exec bool
+	open func() (io.ReadCloser, error)
+}
+
+// ArchiveFolder returns the path to the folder where the archive is extracted.
+func (r *Repo) ArchiveFolder(archiveURL string) string {
+	return filepath.Join(r.RemotesFolder(), ArchiveDirectoryName(archiveURL))
+}
+
+// ArchiveDirectoryName returns the name of the folder for the archive. It
+// includes a hash of the URL because archives often have the same file name,
+// e.g. v1.0.0.tar.gz.
+func ArchiveDirectoryName(archiveURL string) string {
+	name := archiveURL
+	if u, err := url.Parse(archiveURL); err == nil && len(u.Path) > 0 {
+		name = u.Path
+	}
+
+	name = path.Base(name)
+	for _, ext := range archiveExtensions {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	hash := sha256.Sum256([]byte(archiveURL))
+
+	return name + "-" + hex.EncodeToString(hash[:])[:archiveHashLength] + "-archive"
+}
+
+// ArchiveChecksum returns the SHA256 checksum of the extracted archive.
+func (r *Repo) ArchiveChecksum(path string) (string, error) {
+	checksum, err := afero.ReadFile(r.Fs, filepath.Join(path, ArchiveMarker))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(checksum)), nil
+}
+
+// DownloadArchive downloads tar.gz or zip archive and extracts it into the
+// path. When checksum is set the archive must have the same SHA256. A single
+// top-level directory in the archive is stripped.
+func (r *Repo) DownloadArchive(path, archiveURL, checksum string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), archiveTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", archiveURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, archiveMaxSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > archiveMaxSize {
+		return fmt.Errorf("archive %s is larger than %d MB", archiveURL, archiveMaxSize>>20)
+	}
+
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if len(checksum) > 0 && !strings.EqualFold(strings.TrimPrefix(checksum, "sha256:"), actual) {
+		return fmt.Errorf("archive %s checksum mismatch: expected %s, got %s", archiveURL, checksum, actual)
+	}
+
+	tmpPath := path + ".tmp"
+	if err = r.Fs.RemoveAll(tmpPath); err != nil {
+		return err
+	}
+
+	if err = r.extractArchive(data, tmpPath); err != nil {
+		_ = r.Fs.RemoveAll(tmpPath)
+		return fmt.Errorf("couldn't extract %s: %w", archiveURL, err)
+	}
+
+	if err = afero.WriteFile(r.Fs, filepath.Join(tmpPath, ArchiveMarker), []byte(actual+"\n"), archiveFileMode); err != nil {
+		return err
+	}
+
+	if err = r.Fs.RemoveAll(path); err != nil {
+		return err
+	}
+
+	return r.Fs.Rename(tmpPath, path)
+}
+
+func (r *Repo) extractArchive(data []byte, dest string) error {
+	var files []archiveFile
+	var err error
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		files, err = tarGzFiles(data, archiveMaxExtractedSize)
+	case bytes.HasPrefix(data, zipMagic):
+		files, err = zipFiles(data, archiveMaxExtractedSize)
+	default:
+		err = errors.New("unsupported archive format, use tar.gz or zip")
+	}
+	if err != nil {
+		return err
+	}
+
+	prefix := commonDir(files)
+	for _, file := range files {
+		name := path.Clean(file.name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid file path: %s", file.name)
+		}
+		if name == "." || name+"/" == prefix {
+			continue
+		}
+
+		name = strings.TrimPrefix(name, prefix)
+
+		target := filepath.Join(dest, filepath.FromSlash(name))
+		if file.dir {
+			if err = r.Fs.MkdirAll(target, archiveDirMode); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = r.extractFile(file, target); err != nil {
+			return err
+		}
+	}
+
+	return r.Fs.MkdirAll(dest, archiveDirMode)
+}
+
+func (r *Repo) extractFile(file archiveFile, target string) error {
+	if err := r.Fs.MkdirAll(filepath.Dir(target), archiveDirMode); err != nil {
+		return err
+	}
+
+	src, err := file.open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	var mode fs.FileMode = archiveFileMode
+	if file.exec {
+		mode = archiveExecMode
+	}
+
+	dst, err := r.Fs.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+
+	return errors.Join(err, dst.Close())
+}
+
+// tarGzFiles reads the files of tar.gz archive. The total size of the files
+// is limited, so a small archive can't take all the memory.
+func tarGzFiles(data []byte, limit int64) ([]archiveFile, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []archiveFile
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			files = append(files, archiveFile{name: header.Name, dir: true})
+		case tar.TypeReg:
+			content, err := io.ReadAll(&limitedReader{reader: tr, remaining: &limit})
+			if err != nil {
+				return nil, err
+			}
+
+			files = append(files, archiveFile{
+				name: header.Name,
+				exec: header.Mode&0o111 != 0,
+				open: func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader(content)), nil
+				},
+			})
+		}
+	}
+
+	return files, nil
+}
+
+// zipFiles returns the files of zip archive. The total size of the files read
+// is limited.
+func zipFiles(data []byte, limit int64) ([]archiveFile, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]archiveFile, 0, len(zr.File))
+	for _, file := range zr.File {
+		mode := file.Mode()
+		switch {
+		case mode.IsDir():
+			files = append(files, archiveFile{name: file.Name, dir: true})
+		case mode.IsRegular():
+			files = append(files, archiveFile{
+				name: file.Name,
+				exec: mode&0o111 != 0,
+				open: func() (io.ReadCloser, error) {
+					rc, err := file.Open()
+					if err != nil {
+						return nil, err
+					}
+
+					return struct {
+						io.Reader
+						io.Closer
+					}{&limitedReader{reader: rc, remaining: &limit}, rc}, nil
+				},
+			})
+		}
+	}
+
+	return files, nil
+}
+
+// limitedReader fails when more than the remaining bytes are read. The
+// remaining bytes can be shared by several readers.
+type limitedReader struct {
+	reader    io.Reader
+	remaining *int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > *r.remaining+1 {
+		p = p[:*r.remaining+1]
+	}
+
+	n, err := r.reader.Read(p)
+	*r.remaining -= int64(n)
+	if *r.remaining < 0 {
+		return n, errArchiveTooLarge
+	}
+
+	return n, err
+}
+
+// commonDir returns the top-level directory with a trailing slash when all
+// the files are inside it.
+func commonDir(files []archiveFile) string {
+	var prefix string
+	for _, file := range files {
+		name := path.Clean(file.name)
+		top, _, nested := strings.Cut(name, "/")
+		if !nested && !file.dir {
+			return ""
+		}
+
+		if len(prefix) == 0 {
+			prefix = top
+		} else if prefix != top {
+			return ""
+		}
+	}
+
+	if len(prefix) == 0 {
+		return ""
+	}
+
+	return prefix + "/"
+}
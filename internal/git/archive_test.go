@@ -0,0 +1,173 @@
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestArchiveDirectoryName(t *testing.T) {
+	first := ArchiveDirectoryName("https://github.com/a/x/archive/refs/tags/v1.0.0.tar.gz")
+	second := ArchiveDirectoryName("https://github.com/b/y/archive/refs/tags/v1.0.0.tar.gz")
+
+	assert.Regexp(t, `^v1\.0\.0-[0-9a-f]{8}-archive$`, first)
+	assert.Regexp(t, `^v1\.0\.0-[0-9a-f]{8}-archive$`, second)
+	assert.NotEqual(t, first, second)
+}
+
+func TestDownloadArchive(t *testing.T) {
+	for name, tt := range map[string]struct {
+		archive   func(*testing.T, map[string]string) []byte
+		files     map[string]string
+		checksum  string
+		wantFiles map[string]string
+		wantErr   bool
+	}{
+		"tar.gz with top-level directory": {
+			archive: tarGz,
+			files: map[string]string{
+				"configs-1.0/lefthook.yml":                 "pre-commit:\n",
+				"configs-1.0/.lefthook/pre-commit/test.sh": "echo test",
+			},
+			wantFiles: map[string]string{
+				"lefthook.yml":                 "pre-commit:\n",
+				".lefthook/pre-commit/test.sh": "echo test",
+			},
+		},
+		"zip": {
+			archive: zipArchive,
+			files: map[string]string{
+				"lefthook.yml":      "pre-commit:\n",
+				"examples/lint.yml": "pre-push:\n",
+			},
+			wantFiles: map[string]string{
+				"lefthook.yml":      "pre-commit:\n",
+				"examples/lint.yml": "pre-push:\n",
+			},
+		},
+		"checksum mismatch": {
+			archive:  tarGz,
+			files:    map[string]string{"lefthook.yml": "pre-commit:\n"},
+			checksum: "sha256:0000",
+			wantErr:  true,
+		},
+		"path outside the directory": {
+			archive: tarGz,
+			files:   map[string]string{"../lefthook.yml": "pre-commit:\n"},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			data := tt.archive(t, tt.files)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(data)
+			}))
+			defer server.Close()
+
+			fs := afero.NewMemMapFs()
+			repo := &Repo{Fs: fs, InfoPath: "/src/.git/info"}
+			archiveURL := server.URL + "/configs.tar.gz"
+			path := repo.ArchiveFolder(archiveURL)
+			assert.Equal(filepath.Join("/src/.git/info", "lefthook-remotes", ArchiveDirectoryName(archiveURL)), path)
+
+			err := repo.DownloadArchive(path, archiveURL, tt.checksum)
+			if tt.wantErr {
+				assert.Error(err)
+				ok, _ := afero.Exists(fs, path)
+				assert.False(ok)
+				return
+			}
+			assert.NoError(err)
+
+			for name, content := range tt.wantFiles {
+				data, err := afero.ReadFile(fs, filepath.Join(path, name))
+				assert.NoError(err)
+				assert.Equal(content, string(data))
+			}
+
+			sum := sha256.Sum256(data)
+			checksum, err := repo.ArchiveChecksum(path)
+			assert.NoError(err)
+			assert.Equal(hex.EncodeToString(sum[:]), checksum)
+		})
+	}
+}
+
+func TestArchiveSizeLimit(t *testing.T) {
+	files := map[string]string{"a.txt": "12345678", "b.txt": "12345678"}
+
+	for name, tt := range map[string]struct {
+		read  func(data []byte, limit int64) ([]archiveFile, error)
+		data  []byte
+		limit int64
+		err   error
+	}{
+		"tar.gz":                {read: tarGzFiles, data: tarGz(t, files), limit: 16},
+		"tar.gz over the limit": {read: tarGzFiles, data: tarGz(t, files), limit: 15, err: errArchiveTooLarge},
+		"zip":                   {read: zipFiles, data: zipArchive(t, files), limit: 16},
+		"zip over the limit":    {read: zipFiles, data: zipArchive(t, files), limit: 15, err: errArchiveTooLarge},
+	} {
+		t.Run(name, func(t *testing.T) {
+			archiveFiles, err := tt.read(tt.data, tt.limit)
+			for _, file := range archiveFiles {
+				if err != nil {
+					break
+				}
+
+				var rc io.ReadCloser
+				if rc, err = file.open(); err == nil {
+					_, err = io.ReadAll(rc)
+					assert.NoError(t, rc.Close())
+				}
+			}
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package git
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"strings"
+	"sync"
+)
+
+const tagsRefPrefix = "refs/tags/"
+
+var (
+	cmdPushCommits  = []string{"git", "rev-list"}
+	cmdPushLogFiles = []string{"git", "log", "--format=", "--name-only", "--diff-filter=ACMR"}
+	cmdVerifyCommit = []string{"git", "rev-parse", "--verify", "--quiet"}
+)
+
+// PushRef is a ref update Git passes to pre-push hook via STDIN.
+//
+// See https://git-scm.com/docs/githooks#_pre_push.
+type PushRef struct {
+	LocalRef  string
+	LocalSHA  string
+	RemoteRef string
+	RemoteSHA string
+}
+
+// IsDeletion tells whether the remote ref is being deleted.
+func (ref PushRef) IsDeletion() bool {
+	return isZeroSHA(ref.LocalSHA)
+}
+
+// IsNew tells whether the remote ref doesn't exist yet.
+func (ref PushRef) IsNew() bool {
+	return isZeroSHA(ref.RemoteSHA)
+}
+
+// IsTag tells whether the ref is a tag.
+func (ref PushRef) IsTag() bool {
+	return strings.HasPrefix(ref.RemoteRef, tagsRefPrefix)
+}
+
+// ParsePushRefs parses `<local ref> <local sha> <remote ref> <remote sha>` lines
+// of pre-push hook input. Malformed lines are ignored.
+func ParsePushRefs(in io.Reader) ([]PushRef, error) {
+	var refs []PushRef
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 {
+			continue
+		}
+
+		refs = append(refs, PushRef{
+			LocalRef:  fields[0],
+			LocalSHA:  fields[1],
+			RemoteRef: fields[2],
+			RemoteSHA: fields[3],
+		})
+	}
+
+	return refs, scanner.Err()
+}
+
+// SetPushRefs sets the refs being pushed to the remote. After that PushFiles
+// and PushCommits return the files and the commits of these refs.
+func (r *Repo) SetPushRefs(remote string, refs []PushRef) {
+	r.pushRemote = remote
+	r.pushRefs = refs
+
+	r.pushFilesOnce = sync.OnceValues(r.pushRefsFiles)
+	r.pushCommitsOnce = sync.OnceValues(r.pushRefsCommits)
+}
+
+// SetPushRefsFromBase sets the pushed refs to the changes since the base ref
+// of ChangedFiles. It is used when Git doesn't pass the pushed refs.
+func (r *Repo) SetPushRefsFromBase() {
+	if len(r.changedFromRef) == 0 {
+		return
+	}
+
+	to := cmp.Or(r.changedToRef, "HEAD")
+	r.SetPushRefs("", []PushRef{{
+		LocalRef:  to,
+		LocalSHA:  to,
+		RemoteRef: r.changedFromRef,
+		RemoteSHA: r.changedFromRef,
+	}})
+}
+
+// PushRefs returns the refs being pushed, if they are known.
+func (r *Repo) PushRefs() []PushRef {
+	return r.pushRefs
+}
+
+// PushCommits returns the commits being pushed, if the pushed refs are known.
+func (r *Repo) PushCommits() ([]string, error) {
+	if r.pushCommitsOnce == nil {
+		return nil, nil
+	}
+
+	return r.pushCommitsOnce()
+}
+
+func (r *Repo) pushRefsCommits() ([]string, error) {
+	var commits []string
+	for _, ref := range r.pushRefs {
+		if ref.IsDeletion() {
+			continue
+		}
+
+		lines, err := r.Git.CmdLines(append(cmdPushCommits, r.pushRange(ref)...))
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, lines...)
+	}
+
+	return uniq(commits), nil
+}
+
+func (r *Repo) pushRefsFiles() ([]string, error) {
+	var files []string
+	for _, ref := range r.pushRefs {
+		if ref.IsDeletion() {
+			continue
+		}
+
+		// The pushed ref might not be checked out, so the files are not
+		// required to exist in the working tree
+		refFiles, err := r.FindAllFiles(append(cmdPushLogFiles, r.pushRange(ref)...), "")
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, refFiles...)
+	}
+
+	return uniq(files), nil
+}
+
+// pushRange returns the revisions selecting the commits which are pushed
+// with the ref.
+func (r *Repo) pushRange(ref PushRef) []string {
+	// On force push the remote commit is excluded together with its
+	// ancestors, so the rewritten commits are not included.
+	if !ref.IsNew() && r.hasCommit(ref.RemoteSHA) {
+		return []string{ref.LocalSHA, "^" + ref.RemoteSHA}
+	}
+
+	// New ref or unknown remote commit: take the commits missing on the remote.
+	remotes := "--remotes"
+	if len(r.pushRemote) > 0 {
+		remotes += "=" + r.pushRemote
+	}
+
+	return []string{ref.LocalSHA, "--not", remotes}
+}
+
+func (r *Repo) hasCommit(sha string) bool {
+	out, err := r.Git.OnlyDebugLogs().Cmd(append(cmdVerifyCommit, sha+"^{commit}"))
+
+	return err == nil && len(out) > 0
+}
+
+func isZeroSHA(sha string) bool {
+	return len(sha) > 0 && strings.Trim(sha, "0") == ""
+}
+
+func uniq(items []string) []string {
+	seen := make(map[string]struct{}, len(items))
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+
+		seen[item] = struct{}{}
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+const (
+	zero    = "0000000000000000000000000000000000000000"
+	localA  = "1111111111111111111111111111111111111111"
+	remoteA = "2222222222222222222222222222222222222222"
+	localB  = "3333333333333333333333333333333333333333"
+	remoteC = "4444444444444444444444444444444444444444"
+)
+
+func TestParsePushRefs(t *testing.T) {
+	refs, err := ParsePushRefs(strings.NewReader(
+		"refs/heads/main " + localA + " refs/heads/main " + remoteA + "\n" +
+			"refs/tags/v1.0 " + localB + " refs/tags/v1.0 " + zero + "\n" +
+			"\n" +
+			"(delete) " + zero + " refs/heads/old " + remoteC + "\n",
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, []PushRef{
+		{LocalRef: "refs/heads/main", LocalSHA: localA, RemoteRef: "refs/heads/main", RemoteSHA: remoteA},
+		{LocalRef: "refs/tags/v1.0", LocalSHA: localB, RemoteRef: "refs/tags/v1.0", RemoteSHA: zero},
+		{LocalRef: "(delete)", LocalSHA: zero, RemoteRef: "refs/heads/old", RemoteSHA: remoteC},
+	}, refs)
+
+	assert.False(t, refs[0].IsNew())
+	assert.False(t, refs[0].IsTag())
+	assert.True(t, refs[1].IsNew())
+	assert.True(t, refs[1].IsTag())
+	assert.True(t, refs[2].IsDeletion())
+}
+
+func TestPushRefsFiles(t *testing.T) {
+	cmd := cmdtest.NewTracking(func(command string, _ string, out io.Writer) error {
+		var output string
+		switch command {
+		// Existing branch: the remote commit is known
+		case "git rev-parse --verify --quiet " + remoteA + "^{commit}":
+			output = remoteA
+		case "git log --format= --name-only --diff-filter=ACMR " + localA + " ^" + remoteA:
+			output = "a.txt\n\nb.txt\n"
+		case "git rev-list " + localA + " ^" + remoteA:
+			output = localA + "\n"
+		// Force push of a non-current branch: the remote commit is unknown
+		case "git rev-parse --verify --quiet " + remoteC + "^{commit}":
+			return errors.New("exit status 1")
+		case "git log --format= --name-only --diff-filter=ACMR " + localB + " --not --remotes=origin":
+			output = "b.txt\nc.txt\n"
+		case "git rev-list " + localB + " --not --remotes=origin":
+			output = localB + "\n" + localA + "\n"
+		default:
+			t.Fatalf("unexpected command: %s", command)
+		}
+
+		_, err := out.Write([]byte(output))
+		return err
+	})
+
+	logger := loggertest.New()
+	repository := &Repo{
+		Git:    NewCommander(cmd, logger),
+		logger: logger,
+	}
+	repository.ResetCache()
+	repository.SetPushRefs("origin", []PushRef{
+		{LocalRef: "refs/heads/main", LocalSHA: localA, RemoteRef: "refs/heads/main", RemoteSHA: remoteA},
+		{LocalRef: "refs/heads/feature", LocalSHA: localB, RemoteRef: "refs/heads/feature", RemoteSHA: remoteC},
+		{LocalRef: "(delete)", LocalSHA: zero, RemoteRef: "refs/heads/old", RemoteSHA: remoteC},
+	})
+
+	files, err := repository.PushFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "b.txt", "c.txt"}, files)
+
+	commits, err := repository.PushCommits()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{localA, localB}, commits)
+
+	assert.Len(t, repository.PushRefs(), 3)
+}
+
+func TestSetPushRefsFromBase(t *testing.T) {
+	cmd := cmdtest.NewTracking(func(command string, _ string, out io.Writer) error {
+		var output string
+		switch command {
+		case "git rev-parse --verify --quiet origin/main^{commit}":
+			output = remoteA
+		case "git rev-list HEAD ^origin/main":
+			output = localA + "\n"
+		default:
+			t.Fatalf("unexpected command: %s", command)
+		}
+
+		_, err := out.Write([]byte(output))
+		return err
+	})
+
+	logger := loggertest.New()
+	repository := &Repo{
+		Git:    NewCommander(cmd, logger),
+		logger: logger,
+	}
+	repository.ResetCache()
+
+	repository.SetPushRefsFromBase()
+	assert.Empty(t, repository.PushRefs())
+
+	repository.SetChangedFilesRange("origin/main", "")
+	repository.SetPushRefsFromBase()
+
+	commits, err := repository.PushCommits()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{localA}, commits)
+	assert.Equal(t, "origin/main", repository.PushRefs()[0].RemoteRef)
+}
@@ -1,8 +1,11 @@
 package git
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
+
+	"github.com/spf13/afero"
 )
 
 const remotesFolder = "lefthook-remotes"
@@ -95,3 +98,133 @@ func RemoteDirectoryName(url, ref string) string {
 
 	return name
 }
+
+// RemoteCommit returns the commit SHA checked out in the remote repository.
+// It reads the files in .git/ to avoid running Git on every config load.
+func (r *Repo) RemoteCommit(path string) (string, error) {
+	gitDir := filepath.Join(path, ".git")
+	head, err := afero.ReadFile(r.Fs, filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", err
+	}
+
+	ref, ok := strings.CutPrefix(strings.TrimSpace(string(head)), "ref: ")
+	if !ok {
+		return ref, nil
+	}
+
+	if sha, err := afero.ReadFile(r.Fs, filepath.Join(gitDir, filepath.FromSlash(ref))); err == nil {
+		return strings.TrimSpace(string(sha)), nil
+	}
+
+	packedRefs, err := afero.ReadFile(r.Fs, filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return "", fmt.Errorf("couldn't resolve %s: %w", ref, err)
+	}
+
+	for line := range strings.Lines(string(packedRefs)) {
+		if sha, name, found := strings.Cut(strings.TrimSpace(line), " "); found && name == ref {
+			return sha, nil
+		}
+	}
+
+	return "", fmt.Errorf("couldn't resolve %s", ref)
+}
+
+// CheckoutRemote checks out the commit in the remote repository, fetching
+// it if the shallow clone doesn't contain it.
+func (r *Repo) CheckoutRemote(path, commit string) error {
+	if current, err := r.RemoteCommit(path); err == nil && current == commit {
+		return nil
+	}
+
+	git := r.Git.WithoutEnvs("GIT_DIR", "GIT_INDEX_FILE").OnlyDebugLogs()
+
+	_, err := git.Cmd([]string{"git", "-C", path, "checkout", "--quiet", commit})
+	if err == nil {
+		return nil
+	}
+
+	_, err = git.Cmd([]string{
+		"git", "-C", path, "fetch", "--quiet", "--depth", "1",
+		"origin", "--", commit,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = git.Cmd([]string{"git", "-C", path, "checkout", "--quiet", commit})
+
+	return err
+}
+
+// RemoteTags returns the names of the tags in the remote repository.
+func (r *Repo) RemoteTags(url string) ([]string, error) {
+	git := r.Git.WithoutEnvs("GIT_DIR", "GIT_INDEX_FILE").OnlyDebugLogs()
+
+	out, err := git.Cmd([]string{"git", "ls-remote", "--tags", "--refs", "--", url})
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for line := range strings.Lines(out) {
+		_, ref, found := strings.Cut(strings.TrimSpace(line), "\t")
+		if tag, ok := strings.CutPrefix(ref, "refs/tags/"); found && ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// CachedRemoteRefs returns the refs of the fetched copies of the remote.
+// Only the clones of the same URL are considered, the other remotes and
+// archives can have folders with the same prefix.
+func (r *Repo) CachedRemoteRefs(url string) []string {
+	entries, err := afero.ReadDir(r.Fs, r.RemotesFolder())
+	if err != nil {
+		return nil
+	}
+
+	prefix := RemoteDirectoryName(url, "") + "-"
+	var refs []string
+	for _, entry := range entries {
+		ref, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || len(ref) == 0 || !entry.IsDir() {
+			continue
+		}
+
+		if r.remoteOriginURL(filepath.Join(r.RemotesFolder(), entry.Name())) != url {
+			continue
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
+
+// remoteOriginURL returns the URL of the origin of the cloned remote.
+func (r *Repo) remoteOriginURL(path string) string {
+	data, err := afero.ReadFile(r.Fs, filepath.Join(path, ".git", "config"))
+	if err != nil {
+		return ""
+	}
+
+	var origin bool
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			origin = line == `[remote "origin"]`
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if origin && found && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
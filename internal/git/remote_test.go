@@ -0,0 +1,54 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/loggertest"
+)
+
+func TestRemoteTags(t *testing.T) {
+	logger := loggertest.New()
+	repository := &Repo{
+		logger: logger,
+		Git: NewCommander(cmdtest.NewOrdered(t, []cmdtest.Out{
+			{
+				Command: "git ls-remote --tags --refs -- https://github.com/evilmartians/lefthook",
+				Output: "5c4c4e1dd2bd3b43c2c81b2bdbb4de1f4c7bd4dc\trefs/tags/v1.0.0\n" +
+					"9f2c6b0a7e3d4f1c8b5a2e6d9c0f3b7a4e1d8c5b\trefs/tags/v1.1.0\n",
+			},
+		}), logger),
+	}
+
+	tags, err := repository.RemoteTags("https://github.com/evilmartians/lefthook")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0"}, tags)
+}
+
+func TestCachedRemoteRefs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	repository := &Repo{Fs: fs, InfoPath: "/src/.git/info"}
+
+	url := "https://github.com/evilmartians/configs"
+	for folder, origin := range map[string]string{
+		"configs-v1.0.0":                   url,
+		"configs-v1.1.0":                   url,
+		"configs-v2":                       "https://github.com/evilmartians/configs-v2",
+		"configs-v1.2.0":                   "https://github.com/other/configs",
+		"configs-" + "0a1b2c3d-archive":    "",
+		ArchiveDirectoryName(url + ".zip"): "",
+	} {
+		path := filepath.Join(repository.RemotesFolder(), folder)
+		assert.NoError(t, fs.MkdirAll(path, 0o755))
+		if len(origin) > 0 {
+			config := "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = " + origin + "\n\tfetch = +refs/heads/*:refs/remotes/origin/*\n"
+			assert.NoError(t, afero.WriteFile(fs, filepath.Join(path, ".git", "config"), []byte(config), 0o644))
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"v1.0.0", "v1.1.0"}, repository.CachedRemoteRefs(url))
+}
@@ -23,6 +23,7 @@ const (
 	minGitVersion     = "2.31.0"
 	stashMessage      = "lefthook auto backup"
 	unstagedPatchName = "lefthook-unstaged.patch"
+	cacheFolder       = "lefthook-cache"
 	infoDirMode       = 0o775
 )
 
@@ -33,12 +34,16 @@ var (
 	reStashMessage            = regexp.MustCompile(`^(?P<stash>[^ ]+):\s*` + stashMessage)
 	cmdPushFilesBase          = []string{"git", "diff", "--name-only", "HEAD", "@{push}"}
 	cmdPushFilesHead          = []string{"git", "diff", "--name-only", "HEAD"}
+	cmdChangedFiles           = []string{"git", "diff", "--name-only", "--diff-filter=ACMR"}
 	cmdLsTreeFilesHead        = []string{"git", "ls-tree", "-r", "--name-only", "HEAD"}
 	cmdStagedFiles            = []string{"git", "diff", "--name-only", "--cached", "--diff-filter=ACMR"}
 	cmdStagedFilesWithDeleted = []string{"git", "diff", "--name-only", "--cached", "--diff-filter=ACMRD"}
 	cmdStatusShort            = []string{"git", "status", "--short", "--porcelain", "-z"}
 	cmdListStash              = []string{"git", "stash", "list"}
 	cmdAllFiles               = []string{"git", "ls-files", "--cached"}
+	cmdWorktreeFiles          = []string{"git", "ls-files", "--cached", "--others", "--exclude-standard"}
+	cmdWorktreePaths          = []string{"git", "--literal-pathspecs", "ls-files", "--cached", "--others", "--exclude-standard", "--"}
+	cmdIgnoredPath            = []string{"git", "--literal-pathspecs", "ls-files", "--others", "--ignored", "--exclude-standard", "--directory", "--"}
 	cmdCreateStash            = []string{"git", "stash", "create"}
 	cmdStageFiles             = []string{"git", "add", "--force", "--"}
 	cmdRemotes                = []string{"git", "branch", "--remotes"}
@@ -47,6 +52,9 @@ var (
 	cmdGitVersion             = []string{"git", "version"}
 )
 
+// ErrNoBaseRef is returned when the base ref for the changed files is not set.
+var ErrNoBaseRef = errors.New("base ref is not set, use --from-ref option or LEFTHOOK_BASE_REF")
+
 // Repo represents a git repository.
 type Repo struct {
 	Fs     afero.Fs
@@ -60,11 +68,18 @@ type Repo struct {
 
 	unstagedPatchPath string
 	headBranch        string
+	pushRemote        string
+	pushRefs          []PushRef
+	changedFromRef    string
+	changedToRef      string
 
 	stagedFilesOnce            func() ([]string, error)
 	stagedFilesWithDeletedOnce func() ([]string, error)
 	statusShortOnce            func() ([]string, error)
 	stateOnce                  func() State
+	pushFilesOnce              func() ([]string, error)
+	changedFilesOnce           func() ([]string, error)
+	pushCommitsOnce            func() ([]string, error)
 }
 
 // NewRepo returns a Repo or an error, if git repository it not initialized.
@@ -167,6 +182,10 @@ func (r *Repo) ResetCache() {
 		return r.state()
 	})
 
+	r.changedFilesOnce = sync.OnceValues(func() ([]string, error) {
+		return r.changedFiles()
+	})
+
 	r.unstagedPatchPath = filepath.Join(r.InfoPath, unstagedPatchName)
 }
 
@@ -185,8 +204,68 @@ func (r *Repo) AllFiles() ([]string, error) {
 	return r.FindExistingFiles(cmdAllFiles, "")
 }
 
-// PushFiles returns a list of files that are ready to be pushed.
+// WorktreeFiles returns a list of tracked and untracked files which are not ignored.
+func (r *Repo) WorktreeFiles() ([]string, error) {
+	return r.FindExistingFiles(cmdWorktreeFiles, "")
+}
+
+// WorktreeFilesIn returns the files from the given paths which are not ignored.
+func (r *Repo) WorktreeFilesIn(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	out, err := r.Git.BatchedCmd(cmdWorktreePaths, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.extractFiles(strings.Split(out, "\n"), true)
+}
+
+// IsIgnored tells whether the path is ignored by git.
+func (r *Repo) IsIgnored(path string) (bool, error) {
+	out, err := r.Git.Cmd(append(slices.Clone(cmdIgnoredPath), path))
+	if err != nil {
+		return false, err
+	}
+
+	return len(out) > 0, nil
+}
+
+// SetChangedFilesRange sets the refs ChangedFiles compares. Empty `to` means HEAD.
+func (r *Repo) SetChangedFilesRange(from, to string) {
+	r.changedFromRef = from
+	r.changedToRef = to
+}
+
+// ChangedFiles returns a list of files changed since the merge base of the
+// base ref and the target ref. Deleted files are omitted, renamed files
+// are listed with the new names.
+func (r *Repo) ChangedFiles() ([]string, error) {
+	return r.changedFilesOnce()
+}
+
+func (r *Repo) changedFiles() ([]string, error) {
+	if len(r.changedFromRef) == 0 {
+		return nil, ErrNoBaseRef
+	}
+
+	to := r.changedToRef
+	if len(to) == 0 {
+		to = "HEAD"
+	}
+
+	return r.FindExistingFiles(append(cmdChangedFiles, r.changedFromRef+"..."+to, "--"), "")
+}
+
+// PushFiles returns a list of files that are ready to be pushed. When the pushed
+// refs are known, only the files changed in the pushed commits are returned.
 func (r *Repo) PushFiles() ([]string, error) {
+	if r.pushFilesOnce != nil {
+		return r.pushFilesOnce()
+	}
+
 	// Try with @{push}
 	lines, err := r.Git.OnlyDebugLogs().CmdLinesWithinFolder(cmdPushFilesBase, "")
 	if err == nil {
@@ -452,12 +531,11 @@ func (r *Repo) Changeset() (map[string]string, error) {
 		return changeset, nil
 	}
 
-	out, err := r.Git.BatchedCmd([]string{"git", "hash-object", "--"}, pathsToHash)
+	hashes, err := r.HashFiles(pathsToHash)
 	if err != nil {
 		return nil, err
 	}
 
-	hashes := strings.Split(strings.TrimSpace(out), "\n")
 	for i, hash := range hashes {
 		changeset[pathsToHash[i]] = hash
 	}
@@ -465,6 +543,27 @@ func (r *Repo) Changeset() (map[string]string, error) {
 	return changeset, nil
 }
 
+// HashFiles returns the object hashes of the files in the working tree.
+// The hashes are returned in the same order as the files.
+func (r *Repo) HashFiles(files []string) ([]string, error) {
+	out, err := r.Git.BatchedCmd([]string{"git", "hash-object", "--"}, files)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := strings.Split(strings.TrimSpace(out), "\n")
+	if len(hashes) != len(files) {
+		return nil, fmt.Errorf("expected %d hashes, got %d", len(files), len(hashes))
+	}
+
+	return hashes, nil
+}
+
+// CacheFolder returns the path to the folder with cached job results.
+func (r *Repo) CacheFolder() string {
+	return filepath.Join(r.InfoPath, cacheFolder)
+}
+
 func (r *Repo) PrintDiff(files []string) {
 	slices.Sort(files)
 
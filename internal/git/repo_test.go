@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 
@@ -316,3 +317,65 @@ func TestPushFiles(t *testing.T) {
 		}
 	})
 }
+
+func TestChangedFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	root := "/repo"
+	for _, file := range []string{"changed.txt", "renamed.txt"} {
+		if err := afero.WriteFile(fs, filepath.Join(root, file), []byte(file), 0o644); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	for name, tt := range map[string]struct {
+		from, to string
+		command  string
+		err      error
+	}{
+		"without base ref": {
+			err: ErrNoBaseRef,
+		},
+		"with base ref": {
+			from:    "origin/main",
+			command: "git diff --name-only --diff-filter=ACMR origin/main...HEAD --",
+		},
+		"with target ref": {
+			from:    "v1.0",
+			to:      "v1.1",
+			command: "git diff --name-only --diff-filter=ACMR v1.0...v1.1 --",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := cmdtest.NewTracking(func(command string, _ string, out io.Writer) error {
+				if command != tt.command {
+					t.Fatalf("unexpected command: %s", command)
+				}
+
+				_, err := out.Write([]byte("changed.txt\nrenamed.txt\nmissing.txt\n"))
+				return err
+			})
+
+			logger := loggertest.New()
+			repository := &Repo{
+				Fs:       fs,
+				RootPath: root,
+				Git:      NewCommander(cmd, logger),
+				logger:   logger,
+			}
+			repository.ResetCache()
+			repository.SetChangedFilesRange(tt.from, tt.to)
+
+			files, err := repository.ChangedFiles()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			if want := []string{"changed.txt", "renamed.txt"}; !slices.Equal(files, want) {
+				t.Fatalf("expected %v, got %v", want, files)
+			}
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 type State struct {
 	Branch, State string
+
+	// PushRefs are the refs being pushed in pre-push hook.
+	PushRefs []PushRef
 }
 
 const (
@@ -25,7 +28,10 @@ var (
 )
 
 func (r *Repo) State() State {
-	return r.stateOnce()
+	state := r.stateOnce()
+	state.PushRefs = r.pushRefs
+
+	return state
 }
 
 func (r *Repo) state() State {
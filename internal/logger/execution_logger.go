@@ -106,6 +106,30 @@ func (el *ExecutionLogger) LogSkipped(name, reason string) {
 	)
 }
 
+func (el *ExecutionLogger) LogRetry(name string, attempt, attempts int, delay time.Duration) {
+	if !el.Enabled(LogExecutionInfo) {
+		return
+	}
+
+	reason := fmt.Sprintf("attempt %d of %d failed", attempt, attempts)
+	if delay > 0 {
+		reason += ", retrying in " + delay.String()
+	}
+
+	el.Info(
+		lipgloss.NewStyle().
+			BorderLeft(true).
+			BorderStyle(lipgloss.NormalBorder()).
+			BorderForeground(el.Logger.colors.get(ColorYellow)).
+			PaddingLeft(padding).
+			Render(
+				el.Paint(ColorCyan, name) + " " +
+					el.Paint(ColorGray, "(retry)") + " " +
+					el.Paint(ColorYellow, reason),
+			),
+	)
+}
+
 func (el *ExecutionLogger) LogSeparator() {
 	el.log(LevelInfo,
 		lipgloss.NewStyle().
@@ -212,7 +236,7 @@ func (el *ExecutionLogger) LogMeta(hookName string) {
 	)
 }
 
-func (el *ExecutionLogger) LogSuccess(indent int, name string, duration time.Duration) {
+func (el *ExecutionLogger) LogSuccess(indent int, name string, duration time.Duration, attempts int) {
 	var format string
 	if el.NoColors() {
 		format = "%s✓ %s %s"
@@ -220,15 +244,20 @@ func (el *ExecutionLogger) LogSuccess(indent int, name string, duration time.Dur
 		format = "%s✔️ %s %s"
 	}
 
+	details := fmt.Sprintf("(%.2f seconds)", duration.Seconds())
+	if attempts > 1 {
+		details = fmt.Sprintf("(passed on attempt %d, %.2f seconds)", attempts, duration.Seconds())
+	}
+
 	el.Infof(
 		format,
 		strings.Repeat("  ", indent),
 		el.Paint(ColorGreen, name),
-		el.Paint(ColorGray, fmt.Sprintf("(%.2f seconds)", duration.Seconds())),
+		el.Paint(ColorGray, details),
 	)
 }
 
-func (el *ExecutionLogger) LogFailure(indent int, name, failText string, duration time.Duration) {
+func (el *ExecutionLogger) LogFailure(indent int, name, failText string, duration time.Duration, attempts int) {
 	if len(failText) != 0 {
 		failText = fmt.Sprintf(": %s", failText)
 	}
@@ -240,11 +269,59 @@ func (el *ExecutionLogger) LogFailure(indent int, name, failText string, duratio
 		format = "%s🥊 %s%s %s"
 	}
 
+	details := fmt.Sprintf("(%.2f seconds)", duration.Seconds())
+	if attempts > 1 {
+		details = fmt.Sprintf("(failed after %d attempts, %.2f seconds)", attempts, duration.Seconds())
+	}
+
 	el.Infof(
 		format,
 		strings.Repeat("  ", indent),
 		el.Paint(ColorRed, name),
 		el.Paint(ColorRed, failText),
+		el.Paint(ColorGray, details),
+	)
+}
+
+func (el *ExecutionLogger) LogWarning(indent int, name, failText string, duration time.Duration, attempts int) {
+	if len(failText) != 0 {
+		failText = fmt.Sprintf(": %s", failText)
+	}
+
+	var format string
+	if el.NoColors() {
+		format = "%s! %s%s %s"
+	} else {
+		format = "%s⚠️ %s%s %s"
+	}
+
+	details := fmt.Sprintf("(%.2f seconds)", duration.Seconds())
+	if attempts > 1 {
+		details = fmt.Sprintf("(failed after %d attempts, %.2f seconds)", attempts, duration.Seconds())
+	}
+
+	el.Infof(
+		format,
+		strings.Repeat("  ", indent),
+		el.Paint(ColorYellow, name),
+		el.Paint(ColorYellow, failText),
+		el.Paint(ColorGray, details),
+	)
+}
+
+func (el *ExecutionLogger) LogCancelled(indent int, name string, duration time.Duration) {
+	var format string
+	if el.NoColors() {
+		format = "%s- %s %s %s"
+	} else {
+		format = "%s⏹️ %s %s %s"
+	}
+
+	el.Infof(
+		format,
+		strings.Repeat("  ", indent),
+		el.Paint(ColorYellow, name),
+		el.Paint(ColorGray, "(cancelled)"),
 		el.Paint(ColorGray, fmt.Sprintf("(%.2f seconds)", duration.Seconds())),
 	)
 }
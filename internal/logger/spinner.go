@@ -17,6 +17,7 @@ const (
 	spinnerCharSet     = 14
 	spinnerRefreshRate = 100 * time.Millisecond
 	spinnerText        = " waiting"
+	spinnerLockedText  = " locked"
 )
 
 type Spinner struct {
@@ -24,6 +25,7 @@ type Spinner struct {
 	terminalWidth int
 	spinner       *spinner.Spinner
 	names         []string
+	locked        []string
 }
 
 func NewSpinner() *Spinner {
@@ -60,7 +62,7 @@ func (s *Spinner) AddName(name string) {
 	}
 
 	s.names = append(s.names, name)
-	s.spinner.Suffix = formatSpinnerSuffix(s.names, s.terminalWidth)
+	s.updateSuffix()
 }
 
 func (s *Spinner) RemoveName(nameToRemove string) {
@@ -72,18 +74,74 @@ func (s *Spinner) RemoveName(nameToRemove string) {
 		defer s.spinner.Start()
 	}
 
+	s.names = removeName(s.names, nameToRemove)
+	s.updateSuffix()
+}
+
+// AddLockedName shows the name as waiting for a lock.
+func (s *Spinner) AddLockedName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.spinner.Active() {
+		s.spinner.Stop()
+		defer s.spinner.Start()
+	}
+
+	s.locked = append(s.locked, name)
+	s.updateSuffix()
+}
+
+// RemoveLockedName removes the name from the ones waiting for a lock.
+func (s *Spinner) RemoveLockedName(nameToRemove string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.spinner.Active() {
+		s.spinner.Stop()
+		defer s.spinner.Start()
+	}
+
+	s.locked = removeName(s.locked, nameToRemove)
+	s.updateSuffix()
+}
+
+func (s *Spinner) updateSuffix() {
+	locked := formatLockedSuffix(s.locked, s.terminalWidth)
+	width := s.terminalWidth
+	if width > 0 {
+		width -= runewidth.StringWidth(locked)
+	}
+
+	s.spinner.Suffix = formatSpinnerSuffix(s.names, width) + locked
+}
+
+func removeName(names []string, nameToRemove string) []string {
 	j := 0
-	for _, name := range s.names {
+	for _, name := range names {
 		if name == nameToRemove {
 			continue
 		}
 
-		s.names[j] = name
+		names[j] = name
 		j++
 	}
 
-	s.names = s.names[:j]
-	s.spinner.Suffix = formatSpinnerSuffix(s.names, s.terminalWidth)
+	return names[:j]
+}
+
+// formatLockedSuffix lists the names waiting for a lock, or their count if the list is too long.
+func formatLockedSuffix(locked []string, width int) string {
+	if len(locked) == 0 {
+		return ""
+	}
+
+	suffix := fmt.Sprintf(" |%s: %s", spinnerLockedText, strings.Join(locked, ", "))
+	if width <= 0 || runewidth.StringWidth(suffix) <= width/2 {
+		return suffix
+	}
+
+	return fmt.Sprintf(" |%s: %d", spinnerLockedText, len(locked))
 }
 
 func formatSpinnerSuffix(names []string, width int) string {
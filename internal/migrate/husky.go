@@ -0,0 +1,119 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+const huskyDir = ".husky"
+
+var (
+	reHuskyPositional = regexp.MustCompile(`"?\$(?:\{([1-9])\}|([1-9]))"?`)
+	reHuskyAllArgs    = regexp.MustCompile(`"?\$[@*]"?`)
+
+	// Lines containing these words are a part of a shell program, not
+	// separate commands.
+	shellKeywords = []string{
+		"if", "then", "else", "elif", "fi", "for", "while", "until", "do", "done", "case", "esac",
+		"function", "export", "local", "set", "cd", "{", "}",
+	}
+)
+
+// husky translates the scripts in .husky/ directory. Scripts of simple
+// commands become separate jobs of a piped hook, other scripts are run as
+// a single job.
+func (r *Result) husky(fs afero.Fs, root string) error {
+	dir := filepath.Join(root, huskyDir)
+	entries, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		if !config.KnownHook(name) {
+			r.skip("%s/%s: unknown hook", huskyDir, name)
+			continue
+		}
+
+		script, err := afero.ReadFile(fs, filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+
+		lines := huskyCommands(script)
+		if len(lines) == 0 {
+			continue
+		}
+
+		if !slices.ContainsFunc(lines, isShellProgram) {
+			for _, line := range lines {
+				r.addJob(name, &config.Job{Run: strings.TrimSpace(line)})
+			}
+			r.Hooks[name].Piped = len(lines) > 1
+
+			continue
+		}
+
+		r.addJob(name, &config.Job{
+			Name: name,
+			Run:  "set -e\n" + strings.Join(lines, "\n"),
+		})
+	}
+
+	return nil
+}
+
+// huskyCommands returns the lines of a husky script without the shebang,
+// comments, and the husky v8 boilerplate. Positional arguments are
+// replaced with lefthook templates.
+func huskyCommands(script []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(script))
+	for scanner.Scan() {
+		line := strings.TrimRightFunc(scanner.Text(), unicode.IsSpace)
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") || strings.Contains(trimmed, "husky.sh") {
+			continue
+		}
+
+		line = reHuskyPositional.ReplaceAllStringFunc(line, func(arg string) string {
+			match := reHuskyPositional.FindStringSubmatch(arg)
+			return "{" + match[1] + match[2] + "}"
+		})
+		line = reHuskyAllArgs.ReplaceAllString(line, "{0}")
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func isShellProgram(line string) bool {
+	line = strings.TrimSpace(line)
+	if strings.HasSuffix(line, `\`) || strings.HasSuffix(line, "&&") || strings.HasSuffix(line, "||") {
+		return true
+	}
+
+	word, _, _ := strings.Cut(line, " ")
+	if slices.Contains(shellKeywords, strings.TrimSuffix(word, ";")) || strings.HasSuffix(word, "()") {
+		return true
+	}
+
+	// Variable assignment
+	name, _, found := strings.Cut(word, "=")
+	return found && len(name) > 0 && !strings.ContainsAny(name, "-/.")
+}
@@ -0,0 +1,102 @@
+// Package migrate translates configs of other Git hooks managers (husky,
+// pre-commit, overcommit) into lefthook hooks.
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+// Supported sources.
+const (
+	Husky      = "husky"
+	PreCommit  = "pre-commit"
+	Overcommit = "overcommit"
+)
+
+// Sources is the list of supported sources in the order of detection.
+var Sources = []string{Husky, PreCommit, Overcommit}
+
+// Paths of the configs read for each source, relative to the repository root.
+var Paths = map[string]string{
+	Husky:      huskyDir,
+	PreCommit:  preCommitConfig,
+	Overcommit: overcommitConfig,
+}
+
+var reSafeArg = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// Result contains the translated hooks.
+type Result struct {
+	Hooks map[string]*config.Hook
+
+	// Untranslated describes the settings that were not translated.
+	Untranslated []string
+}
+
+// Migrate reads the config of the source tool from root and translates it.
+func Migrate(fs afero.Fs, root, from string) (*Result, error) {
+	res := &Result{Hooks: make(map[string]*config.Hook)}
+
+	var err error
+	switch from {
+	case Husky:
+		err = res.husky(fs, root)
+	case PreCommit:
+		err = res.preCommit(fs, root)
+	case Overcommit:
+		err = res.overcommit(fs, root)
+	default:
+		return nil, fmt.Errorf("unknown source %q, supported: %s", from, strings.Join(Sources, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Detect returns the first source which config exists in root.
+func Detect(fs afero.Fs, root string) (string, bool) {
+	for _, source := range Sources {
+		if ok, _ := afero.Exists(fs, filepath.Join(root, Paths[source])); ok {
+			return source, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *Result) addJob(hookName string, job *config.Job) {
+	hook, ok := r.Hooks[hookName]
+	if !ok {
+		hook = &config.Hook{}
+		r.Hooks[hookName] = hook
+	}
+
+	hook.Jobs = append(hook.Jobs, job)
+}
+
+func (r *Result) skip(format string, args ...any) {
+	r.Untranslated = append(r.Untranslated, fmt.Sprintf(format, args...))
+}
+
+// shellJoin joins the arguments quoting the ones that need it.
+func shellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if reSafeArg.MatchString(arg) {
+			quoted = append(quoted, arg)
+		} else {
+			quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+		}
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,217 @@
+package migrate
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+func TestMigrate(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		from         string
+		files        map[string]string
+		config       string
+		untranslated []string
+	}{
+		"husky": {
+			from: Husky,
+			files: map[string]string{
+				".husky/pre-commit": "#!/usr/bin/env sh\n" +
+					". \"$(dirname -- \"$0\")/_/husky.sh\"\n\n" +
+					"npx lint-staged\n" +
+					"npm test\n",
+				".husky/commit-msg": "npx --no -- commitlint --edit \"$1\"\n",
+				".husky/pre-push": "if [ -n \"$CI\" ]; then\n" +
+					"  exit 0\n" +
+					"fi\n" +
+					"npm run build\n",
+				".husky/_/husky.sh": "# husky",
+				".husky/README":     "# unknown",
+			},
+			config: `commit-msg:
+  jobs:
+    - run: npx --no -- commitlint --edit {1}
+pre-commit:
+  piped: true
+  jobs:
+    - run: npx lint-staged
+    - run: npm test
+pre-push:
+  jobs:
+    - name: pre-push
+      run: |-
+        set -e
+        if [ -n "$CI" ]; then
+          exit 0
+        fi
+        npm run build
+`,
+			untranslated: []string{".husky/README: unknown hook"},
+		},
+		"pre-commit": {
+			from: PreCommit,
+			files: map[string]string{
+				".pre-commit-config.yaml": `
+fail_fast: true
+files: ^src/
+exclude: ^vendor/
+repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.5.0
+    hooks:
+      - id: trailing-whitespace
+  - repo: local
+    hooks:
+      - id: eslint
+        name: eslint
+        entry: npx eslint
+        language: system
+        files: \.(js|ts)$
+        args: [--fix, --max-warnings, "0"]
+      - id: pytest
+        entry: pytest
+        language: system
+        types: [python]
+        pass_filenames: false
+        stages: [push]
+      - id: shellcheck
+        entry: shellcheck
+        language: system
+        files: (^|/)bin/
+      - id: markdownlint
+        entry: markdownlint
+        language: system
+        files: \.md$
+        types: [text, markdown]
+      - id: black
+        entry: black
+        language: python
+`,
+			},
+			config: `pre-commit:
+  fail_fast: true
+  jobs:
+    - name: eslint
+      run: npx eslint --fix --max-warnings 0 {staged_files}
+      glob:
+        - src/*.{js,ts}
+      exclude:
+        - vendor/*
+    - name: shellcheck
+      run: shellcheck {staged_files}
+      glob:
+        - src/*
+      exclude:
+        - vendor/*
+    - name: markdownlint
+      run: markdownlint {staged_files}
+      glob:
+        - src/*.md
+      exclude:
+        - vendor/*
+      file_types:
+        - text
+pre-push:
+  fail_fast: true
+  jobs:
+    - name: pytest
+      run: pytest
+      glob:
+        - src/*.{py,pyi}
+      exclude:
+        - vendor/*
+`,
+			untranslated: []string{
+				`.pre-commit-config.yaml: hook "trailing-whitespace" from https://github.com/pre-commit/pre-commit-hooks requires pre-commit environment, add a job running the tool directly`,
+				`.pre-commit-config.yaml: hook "shellcheck": files regexp "(^|/)bin/"`,
+				`.pre-commit-config.yaml: hook "markdownlint": type "markdown" can't be combined with other file filters`,
+				`.pre-commit-config.yaml: hook "black" with language "python" requires pre-commit environment`,
+			},
+		},
+		"overcommit": {
+			from: Overcommit,
+			files: map[string]string{
+				".overcommit.yml": `
+verify_signatures: false
+PreCommit:
+  ALL:
+    quiet: true
+  RuboCop:
+    enabled: true
+    command: ['bundle', 'exec', 'rubocop']
+    flags: ['--format=emacs']
+    include: '**/*.rb'
+  TrailingWhitespace:
+    enabled: true
+  AuthorName:
+    enabled: false
+PrePush:
+  RSpec:
+    required_executable: 'bin/rspec'
+`,
+			},
+			config: `pre-commit:
+  jobs:
+    - name: RuboCop
+      run: bundle exec rubocop --format=emacs
+      glob:
+        - '**/*.rb'
+pre-push:
+  jobs:
+    - name: RSpec
+      run: bin/rspec
+`,
+			untranslated: []string{
+				`.overcommit.yml: option "verify_signatures"`,
+				`.overcommit.yml: PreCommit: built-in hook "TrailingWhitespace"`,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for path, content := range tt.files {
+				assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, path), []byte(content), 0o755))
+			}
+
+			source, ok := Detect(fs, root)
+			assert.True(t, ok)
+			assert.Equal(t, tt.from, source)
+
+			res, err := Migrate(fs, root, tt.from)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.untranslated, res.Untranslated)
+
+			var out bytes.Buffer
+			cfg := &config.Config{Hooks: res.Hooks}
+			assert.NoError(t, cfg.Dump(config.YAMLFormat, &out))
+			assert.Equal(t, tt.config, out.String())
+		})
+	}
+}
+
+func TestRegexpToGlob(t *testing.T) {
+	for expr, glob := range map[string]string{
+		`\.py$`:            "*.py",
+		`\.(js|ts)$`:       "*.{js,ts}",
+		`\.(?:yml|yaml)$`:  "*.{yml,yaml}",
+		`^docs/`:           "docs/*",
+		`^src/.*\.go$`:     "src/*.go",
+		`^(docs|examples)`: "{docs,examples}*",
+		`(^|/)bin/`:        "",
+		`\.jsx?$`:          "",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			res, ok := regexpToGlob(expr)
+			assert.Equal(t, len(glob) > 0, ok)
+			assert.Equal(t, glob, res)
+		})
+	}
+}
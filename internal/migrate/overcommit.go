@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+const (
+	overcommitConfig  = ".overcommit.yml"
+	overcommitDefault = "ALL"
+)
+
+var overcommitHooks = map[string]string{
+	"CommitMsg":        "commit-msg",
+	"PostCheckout":     "post-checkout",
+	"PostCommit":       "post-commit",
+	"PostMerge":        "post-merge",
+	"PostRewrite":      "post-rewrite",
+	"PreCommit":        "pre-commit",
+	"PrePush":          "pre-push",
+	"PreRebase":        "pre-rebase",
+	"PrepareCommitMsg": "prepare-commit-msg",
+}
+
+type overcommitHook struct {
+	Enabled            *bool      `yaml:"enabled"`
+	Command            stringList `yaml:"command"`
+	RequiredExecutable string     `yaml:"required_executable"`
+	Flags              stringList `yaml:"flags"`
+	Include            stringList `yaml:"include"`
+	Exclude            stringList `yaml:"exclude"`
+}
+
+// stringList is a list which can be set with a single string.
+type stringList []string
+
+func (s *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = []string{node.Value}
+		return nil
+	}
+
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+
+	return nil
+}
+
+// overcommit translates the hooks of .overcommit.yml that have a command.
+// Built-in hooks of overcommit are implemented in Ruby, so they are reported
+// as untranslated.
+func (r *Result) overcommit(fs afero.Fs, root string) error {
+	data, err := afero.ReadFile(fs, filepath.Join(root, overcommitConfig))
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", overcommitConfig, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	cfg := doc.Content[0]
+	if cfg.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: config must be a mapping", overcommitConfig)
+	}
+
+	// Mapping nodes contain keys and values one after another, this keeps
+	// the order of the hooks.
+	for i := 0; i+1 < len(cfg.Content); i += 2 {
+		key, value := cfg.Content[i].Value, cfg.Content[i+1]
+
+		hookName, ok := overcommitHooks[key]
+		if !ok {
+			r.skip("%s: option %q", overcommitConfig, key)
+			continue
+		}
+		if value.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			name := value.Content[j].Value
+			if name == overcommitDefault {
+				continue
+			}
+
+			var hook overcommitHook
+			if err := value.Content[j+1].Decode(&hook); err != nil {
+				return fmt.Errorf("%s: %s: %s: %w", overcommitConfig, key, name, err)
+			}
+
+			if hook.Enabled != nil && !*hook.Enabled {
+				continue
+			}
+
+			command := hook.Command
+			if len(command) == 0 && len(hook.RequiredExecutable) > 0 {
+				command = []string{hook.RequiredExecutable}
+			}
+			if len(command) == 0 {
+				r.skip("%s: %s: built-in hook %q", overcommitConfig, key, name)
+				continue
+			}
+
+			r.addJob(hookName, &config.Job{
+				Name:    name,
+				Run:     shellJoin(slices.Concat(command, hook.Flags)),
+				Glob:    hook.Include,
+				Exclude: hook.Exclude,
+			})
+		}
+	}
+
+	return nil
+}
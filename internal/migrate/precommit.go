@@ -0,0 +1,315 @@
+package migrate
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+const preCommitConfig = ".pre-commit-config.yaml"
+
+var (
+	// Legacy stage names.
+	preCommitStages = map[string]string{
+		"commit":       "pre-commit",
+		"push":         "pre-push",
+		"merge-commit": "pre-merge-commit",
+	}
+
+	// File types of pre-commit (identify tags) supported by file_types option.
+	preCommitFileTypes = []string{"text", "binary", "executable", "symlink"}
+
+	preCommitTypeGlobs = map[string]string{
+		"c":          "*.{c,h}",
+		"css":        "*.css",
+		"go":         "*.go",
+		"html":       "*.{html,htm}",
+		"java":       "*.java",
+		"javascript": "*.{js,mjs,cjs}",
+		"json":       "*.json",
+		"jsx":        "*.jsx",
+		"kotlin":     "*.{kt,kts}",
+		"markdown":   "*.{md,markdown}",
+		"php":        "*.php",
+		"python":     "*.{py,pyi}",
+		"ruby":       "*.rb",
+		"rust":       "*.rs",
+		"scss":       "*.scss",
+		"shell":      "*.{sh,bash,zsh}",
+		"sql":        "*.sql",
+		"swift":      "*.swift",
+		"toml":       "*.toml",
+		"ts":         "*.ts",
+		"tsx":        "*.tsx",
+		"vue":        "*.vue",
+		"yaml":       "*.{yml,yaml}",
+	}
+
+	reRegexpAlternation = regexp.MustCompile(`^\((?:\?:)?((?:[\w/|-]|\\\.)+)\)`)
+	reRegexpLiteral     = regexp.MustCompile(`^[\w/-]+`)
+)
+
+type preCommitFile struct {
+	DefaultStages []string `yaml:"default_stages"`
+	Files         string   `yaml:"files"`
+	Exclude       string   `yaml:"exclude"`
+	FailFast      bool     `yaml:"fail_fast"`
+	Repos         []struct {
+		Repo  string          `yaml:"repo"`
+		Hooks []preCommitHook `yaml:"hooks"`
+	} `yaml:"repos"`
+}
+
+type preCommitHook struct {
+	ID            string   `yaml:"id"`
+	Name          string   `yaml:"name"`
+	Entry         string   `yaml:"entry"`
+	Language      string   `yaml:"language"`
+	Files         string   `yaml:"files"`
+	Exclude       string   `yaml:"exclude"`
+	Types         []string `yaml:"types"`
+	TypesOr       []string `yaml:"types_or"`
+	ExcludeTypes  []string `yaml:"exclude_types"`
+	Args          []string `yaml:"args"`
+	Stages        []string `yaml:"stages"`
+	PassFilenames *bool    `yaml:"pass_filenames"`
+}
+
+// preCommit translates the local hooks of .pre-commit-config.yaml. Hooks from
+// remote repositories depend on the environments managed by pre-commit, so
+// they are reported as untranslated.
+func (r *Result) preCommit(fs afero.Fs, root string) error {
+	data, err := afero.ReadFile(fs, filepath.Join(root, preCommitConfig))
+	if err != nil {
+		return err
+	}
+
+	var cfg preCommitFile
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("%s: %w", preCommitConfig, err)
+	}
+
+	defaultStages := cfg.DefaultStages
+	if len(defaultStages) == 0 {
+		defaultStages = []string{"pre-commit"}
+	}
+
+	for _, repo := range cfg.Repos {
+		for _, hook := range repo.Hooks {
+			if repo.Repo != "local" {
+				r.skip("%s: hook %q from %s requires pre-commit environment, add a job running the tool directly", preCommitConfig, hook.ID, repo.Repo)
+				continue
+			}
+
+			if hook.Language != "system" && hook.Language != "script" {
+				r.skip("%s: hook %q with language %q requires pre-commit environment", preCommitConfig, hook.ID, hook.Language)
+				continue
+			}
+
+			stages := hook.Stages
+			if len(stages) == 0 {
+				stages = defaultStages
+			}
+
+			for _, stage := range stages {
+				stage = cmp.Or(preCommitStages[stage], stage)
+				if !config.KnownHook(stage) {
+					r.skip("%s: hook %q: stage %q", preCommitConfig, hook.ID, stage)
+					continue
+				}
+
+				r.addJob(stage, r.preCommitJob(&cfg, &hook, stage))
+				r.Hooks[stage].FailFast = cfg.FailFast
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Result) preCommitJob(cfg *preCommitFile, hook *preCommitHook, stage string) *config.Job {
+	job := &config.Job{
+		Name: cmp.Or(hook.Name, hook.ID),
+		Run:  hook.Entry,
+	}
+	if len(hook.Args) > 0 {
+		job.Run += " " + shellJoin(hook.Args)
+	}
+
+	if hook.PassFilenames == nil || *hook.PassFilenames {
+		switch stage {
+		case "pre-commit":
+			job.Run += " " + config.SubStagedFiles
+		case "pre-push":
+			job.Run += " " + config.SubPushFiles
+		case "commit-msg", "prepare-commit-msg":
+			job.Run += " {1}"
+		}
+	}
+
+	// pre-commit matches files by all filters, while globs of a job match files
+	// by any of them, so the filters are intersected into the job globs
+	var filters []preCommitFilter
+	for _, files := range []string{cfg.Files, hook.Files} {
+		if len(files) == 0 {
+			continue
+		}
+
+		if glob, ok := regexpToGlob(files); ok {
+			filters = append(filters, preCommitFilter{name: "files regexp " + strconv.Quote(files), globs: []string{glob}})
+		} else {
+			r.skip("%s: hook %q: files regexp %q", preCommitConfig, hook.ID, files)
+		}
+	}
+
+	for _, exclude := range []string{cfg.Exclude, hook.Exclude} {
+		if len(exclude) == 0 {
+			continue
+		}
+
+		if glob, ok := regexpToGlob(exclude); ok {
+			job.Exclude = append(job.Exclude, glob)
+		} else {
+			r.skip("%s: hook %q: exclude regexp %q", preCommitConfig, hook.ID, exclude)
+		}
+	}
+
+	for _, typ := range hook.Types {
+		switch {
+		case typ == "file":
+		case slices.Contains(preCommitFileTypes, typ):
+			job.FileTypes = append(job.FileTypes, typ)
+		case len(preCommitTypeGlobs[typ]) > 0:
+			filters = append(filters, preCommitFilter{name: "type " + strconv.Quote(typ), globs: []string{preCommitTypeGlobs[typ]}})
+		default:
+			r.skip("%s: hook %q: type %q", preCommitConfig, hook.ID, typ)
+		}
+	}
+
+	typesOr := preCommitFilter{name: "types_or " + strconv.Quote(strings.Join(hook.TypesOr, ", "))}
+	for _, typ := range hook.TypesOr {
+		switch {
+		case typ == "file":
+		case slices.Contains(preCommitFileTypes, typ) && len(hook.TypesOr) == 1:
+			job.FileTypes = append(job.FileTypes, typ)
+		case len(preCommitTypeGlobs[typ]) > 0:
+			typesOr.globs = append(typesOr.globs, preCommitTypeGlobs[typ])
+		default:
+			r.skip("%s: hook %q: type %q in types_or", preCommitConfig, hook.ID, typ)
+		}
+	}
+	if len(typesOr.globs) > 0 {
+		filters = append(filters, typesOr)
+	}
+
+	for i, filter := range filters {
+		if i == 0 {
+			job.Glob = filter.globs
+			continue
+		}
+
+		if globs, ok := intersectGlobs(job.Glob, filter.globs); ok {
+			job.Glob = globs
+		} else {
+			r.skip("%s: hook %q: %s can't be combined with other file filters", preCommitConfig, hook.ID, filter.name)
+		}
+	}
+
+	for _, typ := range hook.ExcludeTypes {
+		if slices.Contains(preCommitFileTypes, typ) {
+			job.FileTypes = append(job.FileTypes, "not "+typ)
+		} else {
+			r.skip("%s: hook %q: exclude type %q", preCommitConfig, hook.ID, typ)
+		}
+	}
+
+	return job
+}
+
+// preCommitFilter is a file filter of a pre-commit hook translated into globs
+// matching files by any of them.
+type preCommitFilter struct {
+	name  string
+	globs []string
+}
+
+// intersectGlobs returns the globs matching files matched by both of the given
+// glob lists. Only the globs with a fixed prefix like `src/*` and the globs with
+// a fixed suffix like `*.js` can be intersected.
+func intersectGlobs(a, b []string) ([]string, bool) {
+	result := make([]string, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			glob, ok := intersectGlob(x, y)
+			if !ok {
+				glob, ok = intersectGlob(y, x)
+			}
+			if !ok {
+				return nil, false
+			}
+
+			result = append(result, glob)
+		}
+	}
+
+	return result, true
+}
+
+func intersectGlob(prefix, suffix string) (string, bool) {
+	if strings.Index(prefix, "*") != len(prefix)-1 || strings.LastIndex(suffix, "*") != 0 {
+		return "", false
+	}
+
+	return prefix + suffix[1:], true
+}
+
+// regexpToGlob converts simple regular expressions like `\.(js|ts)$` or
+// `^docs/` into globs.
+func regexpToGlob(expr string) (string, bool) {
+	var glob strings.Builder
+
+	if rest, ok := strings.CutPrefix(expr, "^"); ok {
+		expr = rest
+	} else {
+		glob.WriteString("*")
+	}
+
+	expr, anchored := strings.CutSuffix(expr, "$")
+
+	for len(expr) > 0 {
+		switch {
+		case strings.HasPrefix(expr, `\.`):
+			glob.WriteString(".")
+			expr = expr[2:]
+		case strings.HasPrefix(expr, ".*"):
+			glob.WriteString("*")
+			expr = expr[2:]
+		case reRegexpAlternation.MatchString(expr):
+			match := reRegexpAlternation.FindStringSubmatch(expr)
+			alternatives := strings.ReplaceAll(match[1], `\.`, ".")
+			glob.WriteString("{" + strings.ReplaceAll(alternatives, "|", ",") + "}")
+			expr = expr[len(match[0]):]
+		case reRegexpLiteral.MatchString(expr):
+			literal := reRegexpLiteral.FindString(expr)
+			glob.WriteString(literal)
+			expr = expr[len(literal):]
+		default:
+			return "", false
+		}
+	}
+
+	if !anchored {
+		glob.WriteString("*")
+	}
+
+	return glob.String(), true
+}
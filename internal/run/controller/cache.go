@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+const (
+	cacheDirMode  = 0o755
+	cacheFileMode = 0o644
+)
+
+var errNoFilesToCache = errors.New("no files to cache the result for")
+
+// cacheKey returns the key of a job execution. The key depends on the rendered
+// commands, the environment, the job configuration, and the contents of the files
+// and the script.
+func (c *Controller) cacheKey(
+	scope *scope,
+	job *config.Job,
+	commands []string,
+	files []string,
+	env map[string]string,
+) (string, error) {
+	// Jobs without files depend on the whole repository, their results can't be cached
+	if len(files) == 0 {
+		return "", errNoFilesToCache
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = filepath.Join(scope.root, file)
+	}
+	paths = append(paths, c.scriptPaths(scope, job)...)
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	hashes, err := c.git.HashFiles(paths)
+	if err != nil {
+		return "", err
+	}
+
+	jobConfig, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	write := func(values ...string) {
+		for _, value := range values {
+			_, _ = io.WriteString(hash, value)
+			_, _ = hash.Write([]byte{0})
+		}
+	}
+
+	write(scope.hookName, scope.root, string(jobConfig))
+	write(commands...)
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		write(name, env[name])
+	}
+	for i, path := range paths {
+		write(path, hashes[i])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// scriptPaths returns the paths of the job script found in the source dirs.
+func (c *Controller) scriptPaths(scope *scope, job *config.Job) []string {
+	if len(job.Script) == 0 {
+		return nil
+	}
+
+	var paths []string
+	for _, sourceDir := range scope.opts.SourceDirs {
+		path := filepath.Join(sourceDir, scope.hookName, job.Script)
+		if info, err := c.git.Fs.Stat(path); err == nil && info.Mode().IsRegular() {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// cached checks if the job with the given key has already succeeded.
+func (c *Controller) cached(key string) bool {
+	ok, err := afero.Exists(c.git.Fs, filepath.Join(c.git.CacheFolder(), key))
+	if err != nil {
+		c.logger.Debugf("[lefthook] cache: %s", err)
+	}
+
+	return ok
+}
+
+// cache saves the successful result of the job with the given key.
+func (c *Controller) cache(key string) {
+	if err := c.git.Fs.MkdirAll(c.git.CacheFolder(), cacheDirMode); err != nil {
+		c.logger.Debugf("[lefthook] cache: %s", err)
+		return
+	}
+
+	err := afero.WriteFile(
+		c.git.Fs,
+		filepath.Join(c.git.CacheFolder(), key),
+		[]byte(time.Now().Format(time.RFC3339)),
+		cacheFileMode,
+	)
+	if err != nil {
+		c.logger.Debugf("[lefthook] cache: %s", err)
+	}
+}
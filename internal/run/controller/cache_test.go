@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/configtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+)
+
+func TestCache(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	hash := "5716ca5987cbf97d6bb54920bea6adde242d87e6"
+	cmd := cmdtest.NewTracking(func(command string, _root string, out io.Writer) error {
+		switch {
+		case strings.HasPrefix(command, "git diff --name-only --cached"):
+			_, err := io.WriteString(out, filepath.Join(root, "README.md"))
+			return err
+		case strings.HasPrefix(command, "git hash-object"):
+			_, err := io.WriteString(out, hash)
+			return err
+		}
+
+		return nil
+	})
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "README.md"), []byte{}, 0o644))
+
+	repo := gittest.NewRepositoryBuilder().
+		Root(root).
+		Cmd(cmd).
+		Fs(fs).
+		Build()
+	executor := &flakyExecutor{}
+	controller := newTestController(repo, executor)
+
+	hook := configtest.ParseHook(`
+    jobs:
+      - name: lint
+        run: lint {staged_files}
+        cache: true
+  `)
+	hook.Name = "pre-commit"
+
+	for _, step := range []struct {
+		name    string
+		hash    string
+		noCache bool
+		skipped bool
+		calls   int
+	}{
+		{name: "first run", hash: hash, calls: 1},
+		{name: "same files", hash: hash, skipped: true, calls: 1},
+		{name: "changed files", hash: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", calls: 2},
+		{name: "no cache", hash: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", noCache: true, calls: 3},
+	} {
+		hash = step.hash
+		repo.ResetCache()
+
+		results, err := controller.RunHook(t.Context(), Options{NoCache: step.noCache, SkipLFS: true}, hook)
+		assert.NoError(t, err, step.name)
+		assert.Len(t, results, 1, step.name)
+		assert.Equal(t, step.skipped, results[0].Skip(), step.name)
+		assert.Equal(t, step.calls, executor.calls, step.name)
+	}
+}
+
+func TestCacheScript(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	sourceDir := filepath.Join(root, ".lefthook")
+	script := filepath.Join(sourceDir, "pre-commit", "lint.sh")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, "README.md"), []byte{}, 0o644))
+	assert.NoError(t, afero.WriteFile(fs, script, []byte("#!/bin/sh\nlint \"$@\"\n"), 0o755))
+
+	scriptHash := "5716ca5987cbf97d6bb54920bea6adde242d87e6"
+	cmd := cmdtest.NewTracking(func(command string, _root string, out io.Writer) error {
+		switch {
+		case strings.HasPrefix(command, "git diff --name-only --cached"):
+			_, err := io.WriteString(out, filepath.Join(root, "README.md"))
+			return err
+		case strings.HasPrefix(command, "git hash-object"):
+			_, paths, _ := strings.Cut(command, " -- ")
+			var hashes []string
+			for path := range strings.FieldsSeq(paths) {
+				if path == script {
+					hashes = append(hashes, scriptHash)
+				} else {
+					hashes = append(hashes, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391")
+				}
+			}
+			_, err := io.WriteString(out, strings.Join(hashes, "\n"))
+			return err
+		}
+
+		return nil
+	})
+
+	repo := gittest.NewRepositoryBuilder().
+		Root(root).
+		Cmd(cmd).
+		Fs(fs).
+		Build()
+	executor := &flakyExecutor{}
+	controller := newTestController(repo, executor)
+
+	hook := configtest.ParseHook(`
+    jobs:
+      - name: lint
+        script: lint.sh
+        args: "{staged_files}"
+        cache: true
+  `)
+	hook.Name = "pre-commit"
+
+	for _, step := range []struct {
+		name       string
+		scriptHash string
+		skipped    bool
+		calls      int
+	}{
+		{name: "first run", scriptHash: scriptHash, calls: 1},
+		{name: "same script", scriptHash: scriptHash, skipped: true, calls: 1},
+		{name: "changed script", scriptHash: "d670460b4b4aece5915caf5c68d12f560a9fe3e4", calls: 2},
+	} {
+		scriptHash = step.scriptHash
+		repo.ResetCache()
+
+		results, err := controller.RunHook(t.Context(), Options{SourceDirs: []string{sourceDir}, SkipLFS: true}, hook)
+		assert.NoError(t, err, step.name)
+		assert.Len(t, results, 1, step.name)
+		assert.Equal(t, step.skipped, results[0].Skip(), step.name)
+		assert.Equal(t, step.calls, executor.calls, step.name)
+	}
+}
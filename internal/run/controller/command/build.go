@@ -1,6 +1,8 @@
 package command
 
 import (
+	"slices"
+
 	"github.com/evilmartians/lefthook/v2/internal/config"
 	"github.com/evilmartians/lefthook/v2/internal/git"
 	"github.com/evilmartians/lefthook/v2/internal/logger"
@@ -23,13 +25,15 @@ type JobParams struct {
 }
 
 type BuilderOptions struct {
-	HookName    string
-	GitArgs     []string
-	ForceFiles  []string
-	SourceDirs  []string
-	Templates   map[string]string
-	GlobMatcher string
-	Force       bool
+	HookName     string
+	GitArgs      []string
+	ForceFiles   []string
+	DeletedFiles []string // matched with OnlyMatching but not passed to the jobs
+	SourceDirs   []string
+	Templates    map[string]string
+	GlobMatcher  string
+	Force        bool
+	OnlyMatching bool // skip the jobs not matching any of ForceFiles or DeletedFiles
 }
 
 type Builder struct {
@@ -48,6 +52,10 @@ func NewBuilder(repo *git.Repo, logger *logger.ExecutionLogger, opts BuilderOpti
 
 // BuildCommands returns the list of commands and the list of files touched by the command.
 func (b *Builder) BuildCommands(params *JobParams) ([]string, []string, error) {
+	if b.opts.OnlyMatching && len(b.buildFilter(params).Apply(append(slices.Clone(b.opts.ForceFiles), b.opts.DeletedFiles...))) == 0 {
+		return nil, nil, SkipError{"no matching changed files"}
+	}
+
 	if len(params.Run) != 0 {
 		return b.buildCommand(params)
 	} else {
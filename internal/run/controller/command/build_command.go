@@ -86,7 +86,7 @@ func (b *Builder) buildCommand(params *JobParams) ([]string, []string, error) {
 // buildReplacer creates the replacer with all supported templates for files and arguments.
 func (b *Builder) buildReplacer(params *JobParams) replacer.Replacer {
 	var r replacer.Replacer
-	if len(b.opts.ForceFiles) > 0 {
+	if len(b.opts.ForceFiles) > 0 || b.opts.OnlyMatching {
 		r = replacer.NewMocked(b.logger, b.opts.ForceFiles)
 	} else {
 		r = replacer.New(b.git, b.logger, params.Root, params.FilesCmd)
@@ -45,6 +45,7 @@ func (b *Builder) buildScript(params *JobParams) ([]string, []string, error) {
 	}
 
 	var scriptExists bool
+	var files []string
 	execs := make([]string, 0)
 	for _, sourceDir := range b.opts.SourceDirs {
 		scriptPath := filepath.Join(sourceDir, b.opts.HookName, params.Script)
@@ -82,8 +83,9 @@ func (b *Builder) buildScript(params *JobParams) ([]string, []string, error) {
 		if len(params.Args) > 0 {
 			args = append(args, params.Args)
 			command := strings.Join(args, " ")
-			commands, _ := replacer.ReplaceAndSplit(command, system.MaxCmdLen())
+			commands, replacedFiles := replacer.ReplaceAndSplit(command, system.MaxCmdLen())
 			execs = append(execs, commands...)
+			files = replacedFiles
 		} else {
 			args = append(args, b.opts.GitArgs...)
 			execs = append(execs, strings.Join(args, " "))
@@ -94,5 +96,5 @@ func (b *Builder) buildScript(params *JobParams) ([]string, []string, error) {
 		return nil, nil, scriptNotExistsError{params.Script}
 	}
 
-	return execs, nil, nil
+	return execs, files, nil
 }
@@ -36,10 +36,11 @@ func New(
 	filesCmd string,
 ) Replacer {
 	var (
-		staged = git.StagedFiles
-		push   = git.PushFiles
-		all    = git.AllFiles
-		cmd    = func() ([]string, error) {
+		staged  = git.StagedFiles
+		push    = git.PushFiles
+		all     = git.AllFiles
+		changed = git.ChangedFiles
+		cmd     = func() ([]string, error) {
 			var cmd []string
 			if runtime.GOOS == "windows" {
 				cmd = strings.Split(filesCmd, " ")
@@ -54,10 +55,11 @@ func New(
 		logger: logger,
 		cache:  make(map[string]*entry),
 		files: map[string]func() ([]string, error){
-			config.SubStagedFiles: staged,
-			config.SubPushFiles:   push,
-			config.SubAllFiles:    all,
-			config.SubFiles:       cmd,
+			config.SubStagedFiles:  staged,
+			config.SubPushFiles:    push,
+			config.SubAllFiles:     all,
+			config.SubChangedFiles: changed,
+			config.SubFiles:        cmd,
 		},
 	}
 }
@@ -94,10 +96,11 @@ func NewMocked(logger *logger.ExecutionLogger, files []string) Replacer {
 		logger: logger,
 		cache:  make(map[string]*entry),
 		files: map[string]func() ([]string, error){
-			config.SubStagedFiles: forceFilesFn,
-			config.SubPushFiles:   forceFilesFn,
-			config.SubAllFiles:    forceFilesFn,
-			config.SubFiles:       forceFilesFn,
+			config.SubStagedFiles:  forceFilesFn,
+			config.SubPushFiles:    forceFilesFn,
+			config.SubAllFiles:     forceFilesFn,
+			config.SubChangedFiles: forceFilesFn,
+			config.SubFiles:        forceFilesFn,
 		},
 	}
 }
@@ -0,0 +1,187 @@
+// Package commitmsg implements the built-in Conventional Commits linter.
+//
+// See https://www.conventionalcommits.org/en/v1.0.0/.
+package commitmsg
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+const scissors = "# ------------------------ >8 ------------------------"
+
+var (
+	reHeader = regexp.MustCompile(`^(?P<type>[\w-]+)(?:\((?P<scope>[^()]*)\))?(?P<breaking>!)?:(?: (?P<subject>.*))?$`)
+	reFooter = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[\w-]+)(?:: | #)`)
+
+	// Messages generated by Git are not checked.
+	ignoredPrefixes = []string{"Merge ", "Revert ", "fixup! ", "squash! ", "amend! "}
+)
+
+type message struct {
+	text     string
+	header   string
+	typ      string
+	scope    string
+	subject  string
+	breaking bool
+	body     []string
+	footers  []string
+
+	valid     bool
+	separated bool
+}
+
+// parse parses the commit message ignoring the comments and the diff below
+// the scissors line.
+func parse(text string) *message {
+	var lines []string
+	for line := range strings.Lines(strings.ReplaceAll(text, "\r\n", "\n")) {
+		line = strings.TrimRight(line, "\n")
+		if line == scissors {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+
+	// Trim leading and trailing empty lines
+	for len(lines) > 0 && len(lines[0]) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	msg := &message{text: strings.Join(lines, "\n"), separated: true}
+	if len(lines) == 0 {
+		return msg
+	}
+
+	msg.header = lines[0]
+	if match := reHeader.FindStringSubmatch(msg.header); match != nil {
+		msg.valid = true
+		msg.typ = match[reHeader.SubexpIndex("type")]
+		msg.scope = match[reHeader.SubexpIndex("scope")]
+		msg.breaking = len(match[reHeader.SubexpIndex("breaking")]) > 0
+		msg.subject = match[reHeader.SubexpIndex("subject")]
+	}
+
+	rest := lines[1:]
+	if len(rest) > 0 && len(rest[0]) > 0 {
+		msg.separated = false
+	}
+
+	// Footers are the trailing paragraph where every line is a footer or
+	// a continuation of the previous one.
+	footersStart := len(rest)
+	for i := len(rest) - 1; i >= 0 && len(rest[i]) > 0; i-- {
+		if reFooter.MatchString(rest[i]) {
+			footersStart = i
+		}
+	}
+	if footersStart < len(rest) && (footersStart == 0 || len(rest[footersStart-1]) == 0) {
+		msg.footers = rest[footersStart:]
+		rest = rest[:footersStart]
+	}
+
+	for len(rest) > 0 && len(rest[0]) == 0 {
+		rest = rest[1:]
+	}
+	for len(rest) > 0 && len(rest[len(rest)-1]) == 0 {
+		rest = rest[:len(rest)-1]
+	}
+	msg.body = rest
+
+	return msg
+}
+
+// Lint returns the violations of the rules found in the commit message.
+func Lint(text string, rules *config.LintCommitMsg) ([]string, error) {
+	var ticket *regexp.Regexp
+	if len(rules.Ticket) > 0 {
+		var err error
+		ticket, err = regexp.Compile(rules.Ticket)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ticket regexp: %w", err)
+		}
+	}
+
+	msg := parse(text)
+	if len(msg.header) == 0 {
+		return []string{"message is empty"}, nil
+	}
+
+	for _, prefix := range ignoredPrefixes {
+		if strings.HasPrefix(msg.header, prefix) {
+			return nil, nil
+		}
+	}
+
+	if !msg.valid {
+		return []string{"header must have the format `type(scope): subject`"}, nil
+	}
+
+	var violations []string
+
+	types := rules.Types
+	if len(types) == 0 {
+		types = config.DefaultCommitTypes
+	}
+	if !slices.Contains(types, msg.typ) {
+		violations = append(violations, fmt.Sprintf("type %q is not allowed, use one of: %s", msg.typ, strings.Join(types, ", ")))
+	}
+
+	switch {
+	case len(msg.scope) == 0 && rules.ScopeRequired:
+		violations = append(violations, "scope is required")
+	case len(msg.scope) > 0 && len(rules.Scopes) > 0 && !slices.Contains(rules.Scopes, msg.scope):
+		violations = append(violations, fmt.Sprintf("scope %q is not allowed, use one of: %s", msg.scope, strings.Join(rules.Scopes, ", ")))
+	}
+
+	if len(strings.TrimSpace(msg.subject)) == 0 {
+		violations = append(violations, "subject is empty")
+	} else if length := utf8.RuneCountInString(msg.subject); rules.SubjectMaxLength > 0 && length > rules.SubjectMaxLength {
+		violations = append(violations, fmt.Sprintf("subject is longer than %d characters (%d)", rules.SubjectMaxLength, length))
+	}
+
+	if !msg.separated {
+		violations = append(violations, "body must be separated from the header by an empty line")
+	}
+
+	if rules.BodyMaxLineLength > 0 {
+		for i, line := range msg.body {
+			if length := utf8.RuneCountInString(line); length > rules.BodyMaxLineLength {
+				violations = append(violations, fmt.Sprintf("body line %d is longer than %d characters (%d)", i+1, rules.BodyMaxLineLength, length))
+			}
+		}
+	}
+
+	if rules.BreakingChangeFooter && msg.breaking && !msg.hasBreakingChangeFooter() {
+		violations = append(violations, "breaking change must be described in `BREAKING CHANGE:` footer")
+	}
+
+	if ticket != nil && !ticket.MatchString(msg.text) {
+		violations = append(violations, fmt.Sprintf("message must reference a ticket matching `%s`", rules.Ticket))
+	}
+
+	return violations, nil
+}
+
+func (msg *message) hasBreakingChangeFooter() bool {
+	for _, footer := range msg.footers {
+		if strings.HasPrefix(footer, "BREAKING CHANGE: ") || strings.HasPrefix(footer, "BREAKING-CHANGE: ") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package commitmsg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+)
+
+func TestLint(t *testing.T) {
+	for name, tt := range map[string]struct {
+		message    string
+		rules      config.LintCommitMsg
+		violations []string
+	}{
+		"valid": {
+			message: "feat(api): add users endpoint\n",
+		},
+		"valid with comments": {
+			message: "fix: handle empty input\n\n" +
+				"# Please enter the commit message for your changes.\n" +
+				"# ------------------------ >8 ------------------------\n" +
+				"diff --git a/main.go b/main.go\n",
+			rules: config.LintCommitMsg{SubjectMaxLength: 30, BodyMaxLineLength: 20},
+		},
+		"merge commit": {
+			message: "Merge branch 'main' into feature\n",
+			rules:   config.LintCommitMsg{ScopeRequired: true},
+		},
+		"empty": {
+			message:    "# only comments\n\n",
+			violations: []string{"message is empty"},
+		},
+		"invalid header": {
+			message:    "Add users endpoint\n",
+			violations: []string{"header must have the format `type(scope): subject`"},
+		},
+		"unknown type": {
+			message:    "feature: add users endpoint\n",
+			rules:      config.LintCommitMsg{Types: []string{"feat", "fix"}},
+			violations: []string{`type "feature" is not allowed, use one of: feat, fix`},
+		},
+		"scopes": {
+			message:    "feat(db): add users table\n",
+			rules:      config.LintCommitMsg{Scopes: []string{"api", "web"}},
+			violations: []string{`scope "db" is not allowed, use one of: api, web`},
+		},
+		"scope required": {
+			message:    "feat: add users endpoint\n",
+			rules:      config.LintCommitMsg{ScopeRequired: true},
+			violations: []string{"scope is required"},
+		},
+		"empty subject": {
+			message:    "feat(api): \n",
+			violations: []string{"subject is empty"},
+		},
+		"lengths": {
+			message: "feat: add users endpoint with pagination\n" +
+				"no separation\n" +
+				"a very long line of the commit message body\n\n" +
+				"Refs: a very long footer is not a part of the body",
+			rules: config.LintCommitMsg{SubjectMaxLength: 20, BodyMaxLineLength: 20},
+			violations: []string{
+				"subject is longer than 20 characters (34)",
+				"body must be separated from the header by an empty line",
+				"body line 2 is longer than 20 characters (43)",
+			},
+		},
+		"breaking change without footer": {
+			message:    "feat(api)!: remove users endpoint\n\nUse accounts instead.\n",
+			rules:      config.LintCommitMsg{BreakingChangeFooter: true},
+			violations: []string{"breaking change must be described in `BREAKING CHANGE:` footer"},
+		},
+		"breaking change with footer": {
+			message: "feat(api)!: remove users endpoint\n\n" +
+				"Use accounts instead.\n\n" +
+				"Refs #123\n" +
+				"BREAKING CHANGE: users endpoint is removed\n",
+			rules: config.LintCommitMsg{BreakingChangeFooter: true},
+		},
+		"ticket": {
+			message: "fix: handle empty input\n\nRefs: JIRA-42\n",
+			rules:   config.LintCommitMsg{Ticket: `[A-Z]+-\d+`},
+		},
+		"missing ticket": {
+			message:    "fix: handle empty input\n\n# JIRA-42\n",
+			rules:      config.LintCommitMsg{Ticket: `[A-Z]+-\d+`},
+			violations: []string{"message must reference a ticket matching `[A-Z]+-\\d+`"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			violations, err := Lint(tt.message, &tt.rules)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.violations, violations)
+		})
+	}
+}
+
+func TestLintInvalidTicket(t *testing.T) {
+	_, err := Lint("fix: typo", &config.LintCommitMsg{Ticket: "(JIRA"})
+	assert.EqualError(t, err, "invalid ticket regexp: error parsing regexp: missing closing ): `(JIRA`")
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/expr"
+	"github.com/evilmartians/lefthook/v2/internal/logger"
+)
+
+// checkIf evaluates `if` expression of a hook or a job. Empty expression is always true.
+func (c *Controller) checkIf(expression string, scope *scope, files func() []string) (bool, error) {
+	if len(expression) == 0 {
+		return true, nil
+	}
+
+	state := c.git.State()
+	ok, err := expr.Eval(expression, &expr.Context{
+		Branch:      state.Branch,
+		State:       state.State,
+		Hook:        scope.hookName,
+		Args:        scope.opts.GitArgs,
+		GlobMatcher: scope.opts.GlobMatcher,
+		Env:         os.Getenv,
+		Files:       files,
+		Exists: func(path string) bool {
+			exists, _ := afero.Exists(c.git.Fs, filepath.Join(c.git.RootPath, path))
+			return exists
+		},
+	})
+
+	logger.NewBuilder(c.logger).
+		WithLevel(logger.LevelDebug).
+		WithPrefix("[lefthook] ").
+		WriteLines("if:     ", expression).
+		WriteLines("result: ", ok).
+		Log()
+
+	return ok, err
+}
+
+// hookFiles returns the files the hook works with: the files from the options,
+// staged files, or push files.
+func (c *Controller) hookFiles(scope *scope) []string {
+	var files []string
+	var err error
+
+	switch {
+	case len(scope.opts.Files) > 0:
+		files = scope.opts.Files
+	case config.HookUsesStagedFiles(scope.hookName):
+		files, err = c.git.StagedFiles()
+	case config.HookUsesPushFiles(scope.hookName):
+		files, err = c.git.PushFiles()
+	}
+
+	if err != nil {
+		c.logger.Debugf("[lefthook] couldn't get the files: %s\n", err)
+	}
+
+	return files
+}
@@ -4,9 +4,12 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/evilmartians/lefthook/v2/internal/config"
@@ -15,9 +18,13 @@ import (
 	"github.com/evilmartians/lefthook/v2/internal/run/controller/exec"
 	"github.com/evilmartians/lefthook/v2/internal/run/controller/utils"
 	"github.com/evilmartians/lefthook/v2/internal/run/result"
+	"github.com/evilmartians/lefthook/v2/internal/run/trace"
 	"github.com/evilmartians/lefthook/v2/internal/system"
 )
 
+// errFailFast is the cause of the context cancellation when a job fails with `fail_fast` enabled.
+var errFailFast = errors.New("cancelled because of another job failure")
+
 type Controller struct {
 	git          *git.Repo
 	logger       *logger.ExecutionLogger
@@ -26,21 +33,29 @@ type Controller struct {
 	cmd          system.CommandWithContext
 	skipChecker  *config.SkipChecker
 	filesToStage *stageFilesList
+	terminal     terminal
+	locks        locks
 }
 
 type Options struct {
 	GitArgs           []string
 	ExcludeFiles      []string
 	Files             []string
+	DeletedFiles      []string
 	RunOnlyJobs       []string
 	RunOnlyTags       []string
 	SourceDirs        []string
 	Templates         map[string]string
+	Tracer            *trace.Tracer
 	GlobMatcher       string
 	DisableTTY        bool
 	FailOnChanges     bool
 	FailOnChangesDiff bool
+	FailFast          bool
+	MaxParallel       int
 	Force             bool
+	OnlyMatchingFiles bool
+	NoCache           bool
 	SkipLFS           bool
 	NoStageFixed      bool
 }
@@ -68,13 +83,27 @@ func NewController(repo *git.Repo, logger *logger.ExecutionLogger) *Controller {
 func (c *Controller) RunHook(ctx context.Context, opts Options, hook *config.Hook) ([]result.Result, error) {
 	results := make([]result.Result, 0, len(hook.Jobs))
 
+	if config.HookUsesPushFiles(hook.Name) {
+		opts = c.preparePush(opts)
+	}
+
 	if c.skipChecker.Check(c.git.State, hook.Skip, hook.Only) {
 		c.logger.LogSkipped(hook.Name, "hook setting")
 		return results, nil
 	}
 
+	scope := newScope(hook, opts)
+	if ok, err := c.checkIf(hook.If, scope, func() []string { return c.hookFiles(scope) }); err != nil {
+		return results, fmt.Errorf("if: %w", err)
+	} else if !ok {
+		c.logger.LogSkipped(hook.Name, "hook setting")
+		return results, nil
+	}
+
+	defer opts.Tracer.Span(hook.Name, "hook")()
+
 	if !opts.SkipLFS {
-		if err := c.runLFSHook(ctx, hook.Name, opts.GitArgs); err != nil {
+		if err := c.runLFSHook(ctx, hook.Name, opts.GitArgs, opts.Tracer); err != nil {
 			return results, err
 		}
 	}
@@ -92,11 +121,11 @@ func (c *Controller) RunHook(ctx context.Context, opts Options, hook *config.Hoo
 		c.git,
 		c.logger,
 		c.filesToStage,
+		opts.Tracer,
 		!opts.NoStageFixed && config.HookUsesStagedFiles(hook.Name),
 		opts.FailOnChanges,
 		opts.FailOnChangesDiff,
 	)
-	scope := newScope(hook, opts)
 	err := guard.wrap(func() {
 		if hook.Parallel {
 			results = c.concurrently(ctx, scope, hook.Jobs)
@@ -109,19 +138,51 @@ func (c *Controller) RunHook(ctx context.Context, opts Options, hook *config.Hoo
 }
 
 func (c *Controller) concurrently(ctx context.Context, scope *scope, jobs []*config.Job) []result.Result {
+	deps, err := config.JobsDependencies(jobs)
+	if err != nil {
+		return failAll(jobs, err)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	scope = scope.withCancel(cancel)
+
 	var wg sync.WaitGroup
 
 	results := make([]result.Result, 0, len(jobs))
 	resultsChan := make(chan result.Result, len(jobs))
 
+	// Jobs with `needs` wait until their dependencies are done.
+	// blocked[i] is written before done[i] is closed, so it is safe to read it after.
+	done := make([]chan struct{}, len(jobs))
+	blocked := make([]bool, len(jobs))
+	for i := range jobs {
+		done[i] = make(chan struct{})
+	}
+
 	for i, job := range jobs {
 		id := strconv.Itoa(i)
 
-		wg.Add(1)
-		go func(job *config.Job) {
-			defer wg.Done()
-			resultsChan <- c.runJob(ctx, scope, id, job)
-		}(job)
+		wg.Go(func() {
+			defer close(done[i])
+
+			for _, dep := range deps[i] {
+				<-done[dep]
+			}
+
+			if dep := blockedBy(deps[i], blocked); dep != -1 {
+				blocked[i] = true
+				resultsChan <- c.skipBlocked(scope, job, id, jobs[dep])
+				return
+			}
+
+			result := c.runJob(ctx, scope, id, job)
+			if scope.failFast && result.Failure() {
+				scope.cancel(errFailFast)
+			}
+			blocked[i] = result.Failure()
+			resultsChan <- result
+		})
 	}
 
 	wg.Wait()
@@ -134,24 +195,88 @@ func (c *Controller) concurrently(ctx context.Context, scope *scope, jobs []*con
 }
 
 func (c *Controller) sequentially(ctx context.Context, scope *scope, jobs []*config.Job, piped bool) []result.Result {
+	deps, err := config.JobsDependencies(jobs)
+	if err != nil {
+		return failAll(jobs, err)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	scope = scope.withCancel(cancel)
+
 	results := make([]result.Result, 0, len(jobs))
+	blocked := make([]bool, len(jobs))
 	var failPipe bool
 
-	for i, job := range jobs {
+	// Outputs of the executed jobs by job name, only passed in piped mode
+	outputs := make(map[string]map[string]string)
+
+	for _, i := range config.JobsOrder(deps) {
+		job := jobs[i]
 		id := strconv.Itoa(i)
 
 		if piped && failPipe {
 			c.logger.LogSkipped(job.PrintableName(id), "broken pipe")
+			results = append(results, result.Skip(job.PrintableName(id), "broken pipe"))
 			continue
 		}
 
-		result := c.runJob(ctx, scope, id, job)
+		if dep := blockedBy(deps[i], blocked); dep != -1 {
+			blocked[i] = true
+			results = append(results, c.skipBlocked(scope, job, id, jobs[dep]))
+			continue
+		}
+
+		result := c.runJob(ctx, scope.withOutputs(outputs), id, job)
 		if piped && result.Failure() {
 			failPipe = true
 		}
+		if piped && len(result.Outputs) > 0 {
+			outputs[result.Name] = result.Outputs
+		}
+		if scope.failFast && result.Failure() {
+			scope.cancel(errFailFast)
+		}
+		blocked[i] = result.Failure()
 
 		results = append(results, result)
 	}
 
 	return results
 }
+
+// isCancelled reports whether the jobs were cancelled because of a failure of
+// another job.
+func isCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errFailFast)
+}
+
+// skipBlocked skips the job because one of the jobs it needs has failed.
+func (c *Controller) skipBlocked(scope *scope, job *config.Job, id string, dep *config.Job) result.Result {
+	name := job.PrintableName(id)
+	reason := "needs " + dep.PrintableName("") + " which failed"
+	c.logger.LogSkipped(strings.Join(append(scope.names, name), " ❯ "), reason)
+
+	return result.Skip(name, reason)
+}
+
+// blockedBy returns the index of the first dependency which failed or was
+// skipped because of a failed dependency, or -1.
+func blockedBy(deps []int, blocked []bool) int {
+	for _, dep := range deps {
+		if blocked[dep] {
+			return dep
+		}
+	}
+
+	return -1
+}
+
+func failAll(jobs []*config.Job, err error) []result.Result {
+	results := make([]result.Result, 0, len(jobs))
+	for i, job := range jobs {
+		results = append(results, result.Failure(job.PrintableName(strconv.Itoa(i)), err.Error(), 0))
+	}
+
+	return results
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/git"
 	"github.com/evilmartians/lefthook/v2/internal/run/controller/exec"
 	"github.com/evilmartians/lefthook/v2/internal/run/result"
 	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
@@ -26,6 +28,27 @@ type (
 	executor struct{}
 )
 
+// newTestController returns a controller executing the jobs with the executor.
+// LFS hooks are ignored.
+func newTestController(repo *git.Repo, executor exec.Executor) *Controller {
+	return &Controller{
+		logger:       loggertest.NewExecution(),
+		filesToStage: newStageFilesList(),
+		git:          repo,
+		executor:     executor,
+		cmd:          cmdtest.NewTracking(nil),
+	}
+}
+
+// newTestRepo returns a repository with in-memory files ignoring git commands.
+func newTestRepo(root string) *git.Repo {
+	return gittest.NewRepositoryBuilder().
+		Root(root).
+		Cmd(cmdtest.NewTracking(nil)).
+		Fs(afero.NewMemMapFs()).
+		Build()
+}
+
 func succeeded(name string) result.Result {
 	return result.Success(name, time.Second)
 }
@@ -57,6 +80,8 @@ func TestRunAll(t *testing.T) {
 		existingFiles    []string
 		hook             *config.Hook
 		success, fail    []result.Result
+		cancelled        []result.Result
+		warnings         []result.Result
 		gitCommands      []string
 		force            bool
 		skipLFS          bool
@@ -103,6 +128,192 @@ func TestRunAll(t *testing.T) {
 			},
 			fail: []result.Result{failed("type-check", "")},
 		},
+		"with if conditions": {
+			hookName: "post-commit",
+			branch:   "feature/if",
+			existingFiles: []string{
+				filepath.Join(root, "README.md"),
+			},
+			hook: configtest.ParseHook(`
+        jobs:
+          - name: hook
+            run: success
+            if: hook == "post-commit"
+          - name: exists
+            run: success
+            if: exists("README.md") && !exists("Gemfile")
+          - name: branch
+            run: success
+            if: branch =~ "main"
+          - name: group
+            if: branch =~ "feature/*"
+            group:
+              jobs:
+                - name: nested
+                  run: success
+                  if: env.LEFTHOOK_TEST_UNDEFINED
+          - name: invalid
+            run: success
+            if: commit == "HEAD"
+      `),
+			success: []result.Result{succeeded("hook"), succeeded("exists")},
+			fail:    []result.Result{failed("invalid", `if: unknown variable "commit" at position 1`)},
+		},
+		"with hook if condition": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        if: hook == "pre-commit"
+        jobs:
+          - name: test
+            run: success
+      `),
+		},
+		"with needs in parallel": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        parallel: true
+        jobs:
+          - name: test
+            run: success
+            needs: [lint, type-check]
+          - name: lint
+            run: success
+          - name: type-check
+            run: success
+      `),
+			success: []result.Result{
+				succeeded("test"),
+				succeeded("lint"),
+				succeeded("type-check"),
+			},
+		},
+		"with needs of a failed job": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        parallel: true
+        jobs:
+          - name: test
+            run: success
+            needs: [lint]
+          - name: lint
+            run: fail
+          - name: e2e
+            run: success
+            needs: [test]
+          - name: type-check
+            run: success
+      `),
+			success: []result.Result{succeeded("type-check")},
+			fail:    []result.Result{failed("lint", "")},
+		},
+		"with needs in sequential group": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        jobs:
+          - group:
+              jobs:
+                - name: lint
+                  run: fail
+                - name: test
+                  run: success
+                  needs: [lint]
+                - name: type-check
+                  run: success
+      `),
+			fail: []result.Result{failed("group (0)", "")},
+		},
+		"with needs of a later job in sequential hook": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        jobs:
+          - name: test
+            run: success
+            needs: [lint]
+          - name: lint
+            run: fail
+          - name: type-check
+            run: success
+      `),
+			success: []result.Result{succeeded("type-check")},
+			fail:    []result.Result{failed("lint", "")},
+		},
+		"with needs of matrix jobs": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        jobs:
+          - name: report
+            run: success
+            needs: [test]
+          - name: test (success)
+            run: success
+          - name: test (fail)
+            run: fail
+      `),
+			success: []result.Result{succeeded("test (success)")},
+			fail:    []result.Result{failed("test (fail)", "")},
+		},
+		"with fail_fast in sequential hook": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        fail_fast: true
+        jobs:
+          - name: lint
+            run: success
+          - name: test
+            run: fail
+          - name: e2e
+            run: success
+      `),
+			success:   []result.Result{succeeded("lint")},
+			fail:      []result.Result{failed("test", "")},
+			cancelled: []result.Result{result.Cancelled("e2e", 0)},
+		},
+		"with fail_fast in group": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        jobs:
+          - group:
+              fail_fast: true
+              jobs:
+                - name: test
+                  run: fail
+                - name: e2e
+                  run: success
+          - name: lint
+            run: success
+      `),
+			success: []result.Result{succeeded("lint")},
+			fail:    []result.Result{failed("group (0)", "")},
+		},
+		"with allow_failure": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        jobs:
+          - name: spell
+            run: fail
+            allow_failure: true
+          - name: lint
+            run: success
+          - group:
+              jobs:
+                - name: docs
+                  run: fail
+                  allow_failure: true
+      `),
+			success:  []result.Result{succeeded("lint")},
+			warnings: []result.Result{result.Warning("spell", "", 0), result.Warning("group (2)", "", 0)},
+		},
+		"with needs of unknown job": {
+			hookName: "post-commit",
+			hook: configtest.ParseHook(`
+        parallel: true
+        jobs:
+          - name: test
+            run: success
+            needs: [lint]
+      `),
+			fail: []result.Result{failed("test", `job "test" needs unknown job "lint"`)},
+		},
 		"with exclude tags": {
 			hookName: "post-commit",
 			hook: configtest.ParseHook(`
@@ -608,13 +819,7 @@ func TestRunAll(t *testing.T) {
 			Cmd(cmdExecutor).
 			Fs(fs).
 			Build()
-		controller := &Controller{
-			logger:       loggertest.NewExecution(),
-			filesToStage: newStageFilesList(),
-			git:          repo,
-			executor:     executor{},
-			cmd:          cmdtest.NewTracking(nil), // lfs hooks ignored in this test
-		}
+		controller := newTestController(repo, executor{})
 		cmdExecutor.Reset()
 
 		for _, file := range tt.existingFiles {
@@ -641,17 +846,24 @@ func TestRunAll(t *testing.T) {
 			results, err := controller.RunHook(t.Context(), opts, tt.hook)
 			assert.NoError(err)
 
-			var success, fail []result.Result
-			for _, result := range results {
-				if result.Success() {
-					success = append(success, succeeded(result.Name))
-				} else if result.Failure() {
-					fail = append(fail, failed(result.Name, result.Text()))
+			var success, fail, cancelled, warnings []result.Result
+			for _, res := range results {
+				switch {
+				case res.Success():
+					success = append(success, succeeded(res.Name))
+				case res.Failure():
+					fail = append(fail, failed(res.Name, res.Text()))
+				case res.Cancelled():
+					cancelled = append(cancelled, result.Cancelled(res.Name, 0))
+				case res.Warning():
+					warnings = append(warnings, result.Warning(res.Name, res.Text(), 0))
 				}
 			}
 
 			assert.ElementsMatch(success, tt.success)
 			assert.ElementsMatch(fail, tt.fail)
+			assert.ElementsMatch(cancelled, tt.cancelled)
+			assert.ElementsMatch(warnings, tt.warnings)
 
 			if len(tt.gitCommands) > 0 {
 				assert.Len(cmdExecutor.Commands, len(tt.gitCommands))
@@ -666,3 +878,265 @@ func TestRunAll(t *testing.T) {
 		})
 	}
 }
+
+// blockingExecutor fails commands starting with "fail" and blocks other
+// commands until the context is cancelled.
+type blockingExecutor struct{}
+
+func (e blockingExecutor) Execute(ctx context.Context, opts exec.Options, _in io.Reader, _out io.Writer) error {
+	if strings.HasPrefix(opts.Commands[0], "fail") {
+		return errors.New(opts.Commands[0])
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+func TestFailFast(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		hook      string
+		failFast  bool
+		cancelled []string
+		failed    []string
+	}{
+		"hook setting": {
+			hook: `
+        parallel: true
+        fail_fast: true
+        jobs:
+          - name: lint
+            run: fail
+          - name: test
+            run: wait
+          - name: e2e
+            run: wait
+      `,
+			cancelled: []string{"test", "e2e"},
+			failed:    []string{"lint"},
+		},
+		"option": {
+			hook: `
+        parallel: true
+        jobs:
+          - name: lint
+            run: fail
+          - name: test
+            run: wait
+      `,
+			failFast:  true,
+			cancelled: []string{"test"},
+			failed:    []string{"lint"},
+		},
+		"group setting": {
+			hook: `
+        parallel: true
+        jobs:
+          - name: group
+            group:
+              parallel: true
+              fail_fast: true
+              jobs:
+                - name: lint
+                  run: fail
+                - name: test
+                  run: wait
+      `,
+			failed: []string{"group"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			controller := newTestController(newTestRepo(root), blockingExecutor{})
+
+			hook := configtest.ParseHook(tt.hook)
+			hook.Name = "post-commit"
+			results, err := controller.RunHook(t.Context(), Options{
+				FailFast:    tt.failFast,
+				MaxParallel: 4,
+				SkipLFS:     true,
+			}, hook)
+			assert.NoError(t, err)
+
+			var cancelled, failed []string
+			for _, res := range results {
+				switch {
+				case res.Cancelled():
+					cancelled = append(cancelled, res.Name)
+				case res.Failure():
+					failed = append(failed, res.Name)
+				}
+			}
+
+			assert.ElementsMatch(t, tt.cancelled, cancelled)
+			assert.ElementsMatch(t, tt.failed, failed)
+		})
+	}
+}
+
+// stubbornExecutor fails "fail" commands once the other commands start.
+// "stubborn" commands ignore the cancellation until a "wait" command gets
+// cancelled.
+type stubbornExecutor struct {
+	started   *sync.WaitGroup
+	cancelled chan struct{}
+}
+
+func (e stubbornExecutor) Execute(ctx context.Context, opts exec.Options, _in io.Reader, _out io.Writer) error {
+	switch {
+	case strings.HasPrefix(opts.Commands[0], "fail"):
+		e.started.Wait()
+		return errors.New(opts.Commands[0])
+	case strings.HasPrefix(opts.Commands[0], "stubborn"):
+		e.started.Done()
+		select {
+		case <-e.cancelled:
+			return nil
+		case <-time.After(time.Second):
+			return errors.New("wait was not cancelled")
+		}
+	default:
+		e.started.Done()
+		<-ctx.Done()
+		close(e.cancelled)
+		return ctx.Err()
+	}
+}
+
+func TestFailFastInNestedGroup(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	executor := stubbornExecutor{started: &sync.WaitGroup{}, cancelled: make(chan struct{})}
+	executor.started.Add(2)
+	controller := newTestController(newTestRepo(root), executor)
+
+	hook := configtest.ParseHook(`
+    parallel: true
+    fail_fast: true
+    jobs:
+      - name: group
+        group:
+          parallel: true
+          jobs:
+            - name: nested
+              group:
+                parallel: true
+                jobs:
+                  - name: lint
+                    run: fail
+                  - name: format
+                    run: stubborn
+      - name: test
+        run: wait
+  `)
+	hook.Name = "post-commit"
+	results, err := controller.RunHook(t.Context(), Options{MaxParallel: 4, SkipLFS: true}, hook)
+	assert.NoError(t, err)
+
+	// The sibling of the group is cancelled while the group is still running
+	statuses := make(map[string]string)
+	for _, res := range results {
+		statuses[res.Name] = res.Status()
+		for _, nested := range res.Sub {
+			for _, sub := range nested.Sub {
+				statuses[sub.Name] = sub.Status()
+			}
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"group":  "failure",
+		"test":   "cancelled",
+		"lint":   "failure",
+		"format": "success",
+	}, statuses)
+}
+
+// flakyExecutor fails the first `failures` executions.
+type flakyExecutor struct {
+	failures int
+	calls    int
+}
+
+func (e *flakyExecutor) Execute(_ctx context.Context, _opts exec.Options, _in io.Reader, _out io.Writer) error {
+	e.calls++
+	if e.calls <= e.failures {
+		return errors.New("flaky")
+	}
+
+	return nil
+}
+
+func TestRetries(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		hook     string
+		failures int
+		success  bool
+		attempts int
+	}{
+		"passes without retries": {
+			hook: `
+        jobs:
+          - name: test
+            run: test
+            retries: 2
+      `,
+			success:  true,
+			attempts: 1,
+		},
+		"passes on retry": {
+			hook: `
+        jobs:
+          - name: test
+            run: test
+            retries: 2
+            retry_delay: 1ms
+      `,
+			failures: 1,
+			success:  true,
+			attempts: 2,
+		},
+		"fails after all attempts": {
+			hook: `
+        jobs:
+          - name: test
+            run: test
+            retries: 2
+      `,
+			failures: 5,
+			attempts: 3,
+		},
+		"no retries": {
+			hook: `
+        jobs:
+          - name: test
+            run: test
+      `,
+			failures: 1,
+			attempts: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			executor := &flakyExecutor{failures: tt.failures}
+			controller := newTestController(newTestRepo(root), executor)
+
+			hook := configtest.ParseHook(tt.hook)
+			hook.Name = "post-commit"
+			results, err := controller.RunHook(t.Context(), Options{SkipLFS: true}, hook)
+			assert.NoError(t, err)
+
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.success, results[0].Success())
+			assert.Equal(t, tt.attempts, results[0].Attempts)
+			assert.Equal(t, tt.attempts, executor.calls)
+		})
+	}
+}
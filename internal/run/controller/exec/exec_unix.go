@@ -92,6 +92,11 @@ func (e CommandExecutor) execute(ctx context.Context, cmdstr string, args *execu
 			return err
 		}
 	case isatty.IsTerminal(os.Stdout.Fd()):
+		// pty.Start makes the child a session leader, so its PID is also
+		// the process group ID. Kill the whole group on cancel.
+		command.Cancel = func() error {
+			return syscall.Kill(-command.Process.Pid, syscall.SIGKILL)
+		}
 		p, err := pty.Start(command)
 		if err != nil {
 			return err
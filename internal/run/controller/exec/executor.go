@@ -2,7 +2,9 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"io"
+	"os/exec"
 
 	"github.com/evilmartians/lefthook/v2/internal/logger"
 )
@@ -24,3 +26,18 @@ type Executor interface {
 func New(logger *logger.ExecutionLogger) Executor {
 	return CommandExecutor{logger: logger}
 }
+
+// ExitCode returns the exit code of the failed command, 0 if there was no
+// error, or -1 if the command was not executed or was killed.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
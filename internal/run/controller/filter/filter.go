@@ -95,6 +95,32 @@ func byGlob(vs []string, matchers []string, globMatcher string) []string {
 	return vsf
 }
 
+// Compile returns a function matching the paths by the glob pattern the same
+// way the files of the jobs are matched.
+func Compile(pattern string, globMatcher string) (func(path string) bool, error) {
+	lowerPattern := strings.ToLower(pattern)
+
+	if globMatcher == "doublestar" {
+		if !doublestar.ValidatePattern(lowerPattern) {
+			return nil, doublestar.ErrBadPattern
+		}
+
+		return func(path string) bool {
+			matched, err := doublestar.Match(lowerPattern, strings.ToLower(path))
+			return err == nil && matched
+		}, nil
+	}
+
+	g, err := glob.Compile(lowerPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(path string) bool {
+		return g.Match(strings.ToLower(path))
+	}, nil
+}
+
 func matchFiles(vs []string, matcher string, globMatcher string) []string {
 	var matched []string
 	lowerMatcher := strings.ToLower(matcher)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evilmartians/lefthook/v2/internal/git"
 	"github.com/evilmartians/lefthook/v2/internal/logger"
+	"github.com/evilmartians/lefthook/v2/internal/run/trace"
 )
 
 var errRestorationConflict = errors.New("conflict while merging unstaged changes")
@@ -23,6 +24,7 @@ type guard struct {
 	git          *git.Repo
 	logger       *logger.ExecutionLogger
 	filesToStage *stageFilesList
+	tracer       *trace.Tracer
 
 	stashUnstagedChanges bool
 	failOnChanges        bool
@@ -33,6 +35,7 @@ func newGuard(
 	repo *git.Repo,
 	logger *logger.ExecutionLogger,
 	filesToStage *stageFilesList,
+	tracer *trace.Tracer,
 	stashUnstagedChanges bool,
 	failOnChanges bool,
 	failOnChangesDiff bool,
@@ -40,6 +43,7 @@ func newGuard(
 	return &guard{
 		git:                  repo,
 		logger:               logger,
+		tracer:               tracer,
 		stashUnstagedChanges: stashUnstagedChanges,
 		filesToStage:         filesToStage,
 		failOnChanges:        failOnChanges,
@@ -88,8 +92,11 @@ func (g *guard) withHiddenUnstagedChanges(fn func() error) error {
 
 	g.logger.Debug("[lefthook] saving partially staged files")
 
+	endHide := g.tracer.Span("hide unstaged changes", "guard")
+
 	if err := g.git.SaveUnstagedChanges(partiallyStagedFiles); err != nil {
 		g.logger.Warnf("Failed to save unstaged changes: %s\n", err)
+		endHide()
 		return err
 	}
 
@@ -101,11 +108,16 @@ func (g *guard) withHiddenUnstagedChanges(fn func() error) error {
 
 	if err := g.git.RevertUnstagedChanges(partiallyStagedFiles); err != nil {
 		g.logger.Warnf("Failed to hide unstaged files: %s", err)
+		endHide()
 		return err
 	}
+	endHide()
 
 	wrappedErr := fn()
 
+	endRestore := g.tracer.Span("restore unstaged changes", "guard")
+	defer endRestore()
+
 	var failOnChangesErr *FailOnChangesError
 	if errors.As(wrappedErr, &failOnChangesErr) {
 		if err := g.git.RevertUnstagedChanges(failOnChangesErr.changedFiles); err != nil {
@@ -151,14 +163,18 @@ func (g *guard) withFailOnChanges(fn func()) error {
 		return nil
 	}
 
+	endChangeset := g.tracer.Span("changeset", "guard")
 	changesetBefore, err := g.git.Changeset()
+	endChangeset()
 	if err != nil {
 		return fmt.Errorf("changeset calculation failed: %w", err)
 	}
 
 	fn()
 
+	endChangeset = g.tracer.Span("changeset", "guard")
 	changesetAfter, err := g.git.Changeset()
+	endChangeset()
 	if err != nil {
 		return fmt.Errorf("changeset calculation failed: %w", err)
 	}
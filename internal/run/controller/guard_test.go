@@ -199,6 +199,7 @@ func Test_guard_wrap(t *testing.T) {
 				repo,
 				loggertest.NewExecution(),
 				newStageFilesList(),
+				nil,
 				tt.stashUnstagedChanges,
 				tt.failOnChanges,
 				tt.failOnChangesDiff,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"maps"
+	"os"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -18,7 +19,7 @@ import (
 )
 
 const (
-	invalidJobError = "either `run`,`script`, or `group` must be provided for a job"
+	invalidJobError = "either `run`, `script`, `lint_commit_msg`, or `group` must be provided for a job"
 	emptyGroupError = "group must have `jobs`"
 )
 
@@ -27,7 +28,10 @@ func (c *Controller) runJob(ctx context.Context, scope *scope, id string, job *c
 	if len(job.Run) > 0 && len(job.Script) > 0 {
 		return result.Failure(job.PrintableName(id), invalidJobError, 0)
 	}
-	if len(job.Run) == 0 && len(job.Script) == 0 && job.Group == nil {
+	if job.LintCommitMsg != nil && (len(job.Run) > 0 || len(job.Script) > 0) {
+		return result.Failure(job.PrintableName(id), invalidJobError, 0)
+	}
+	if len(job.Run) == 0 && len(job.Script) == 0 && job.LintCommitMsg == nil && job.Group == nil {
 		return result.Failure(job.PrintableName(id), invalidJobError, 0)
 	}
 
@@ -37,13 +41,13 @@ func (c *Controller) runJob(ctx context.Context, scope *scope, id string, job *c
 		defer c.logger.Spinner.Start()
 	}
 
-	if len(job.Run) != 0 || len(job.Script) != 0 {
+	if len(job.Run) != 0 || len(job.Script) != 0 || job.LintCommitMsg != nil {
 		if len(scope.opts.RunOnlyJobs) != 0 && !slices.Contains(scope.opts.RunOnlyJobs, job.Name) {
-			return result.Skip(job.PrintableName(id))
+			return result.Skip(job.PrintableName(id), "filtered by --job")
 		}
 
 		if len(scope.opts.RunOnlyTags) != 0 && (!utils.Intersect(scope.opts.RunOnlyTags, job.Tags) && !utils.Intersect(scope.opts.RunOnlyTags, scope.tags)) {
-			return result.Skip(job.PrintableName(id))
+			return result.Skip(job.PrintableName(id), "filtered by --tag")
 		}
 
 		return c.runSingleJob(ctx, scope, id, job)
@@ -56,21 +60,43 @@ func (c *Controller) runJob(ctx context.Context, scope *scope, id string, job *c
 		if reason := c.skipReason(extendedScope, job, groupName); len(reason) > 0 {
 			c.logger.LogSkipped(groupName, reason)
 
-			return result.Skip(groupName)
+			return result.Skip(groupName, reason)
+		}
+
+		ok, err := c.checkIf(job.If, extendedScope, func() []string { return c.hookFiles(extendedScope) })
+		if err != nil {
+			c.logger.LogSkipped(groupName, "if: "+err.Error())
+
+			return result.Failure(groupName, "if: "+err.Error(), 0)
+		}
+		if !ok {
+			c.logger.LogSkipped(groupName, "by condition")
+
+			return result.Skip(groupName, "by condition")
 		}
 
 		extendedScope.names = append(extendedScope.names, groupName)
+		extendedScope.failFast = scope.failFast || job.Group.FailFast
+		if !scope.failFast {
+			// fail_fast of the group must not cancel the jobs outside of it
+			extendedScope.cancel = nil
+		}
+		if job.Group.MaxParallel > 0 {
+			extendedScope.slots = append(slices.Clone(scope.slots), newSemaphore(job.Group.MaxParallel))
+		}
 
 		if len(job.Group.Jobs) == 0 {
 			return result.Failure(groupName, emptyGroupError, 0)
 		}
 
+		endTrace := scope.opts.Tracer.Group(strings.Join(extendedScope.names, " ❯ "))
 		var results []result.Result
 		if job.Group.Parallel {
 			results = c.concurrently(ctx, extendedScope, job.Group.Jobs)
 		} else {
 			results = c.sequentially(ctx, extendedScope, job.Group.Jobs, job.Group.Piped)
 		}
+		endTrace()
 
 		return result.Group(groupName, results)
 	}
@@ -88,98 +114,268 @@ func (c *Controller) runSingleJob(ctx context.Context, scope *scope, id string,
 	if reason := c.skipReason(scope, job, name); len(reason) > 0 {
 		c.logger.LogSkipped(logName, reason)
 
-		return result.Skip(name)
+		return result.Skip(name, reason)
 	}
 
-	builder := command.NewBuilder(c.git, c.logger, command.BuilderOptions{
-		HookName:    scope.hookName,
-		ForceFiles:  scope.opts.Files,
-		Force:       scope.opts.Force,
-		SourceDirs:  scope.opts.SourceDirs,
-		GitArgs:     scope.opts.GitArgs,
-		Templates:   scope.opts.Templates,
-		GlobMatcher: scope.opts.GlobMatcher,
-	})
-	commands, files, err := builder.BuildCommands(&command.JobParams{
-		Name:         name,
-		Run:          job.Run,
-		Runner:       job.Runner,
-		Args:         job.Args,
-		Script:       job.Script,
-		Only:         job.Only,
-		Skip:         job.Skip,
-		Root:         scope.root,
-		FileTypes:    scope.fileTypes,
-		Glob:         scope.glob,
-		FilesCmd:     scope.filesCmd,
-		Tags:         scope.tags,
-		ExcludeFiles: scope.excludeFiles,
-	})
+	commands, files, err := c.buildCommands(scope, name, job)
 	if err != nil {
 		c.logger.LogSkipped(logName, err.Error())
 
 		var skipErr command.SkipError
 		if errors.As(err, &skipErr) {
-			return result.Skip(name)
+			return result.Skip(name, err.Error())
 		}
 
 		return result.Failure(name, err.Error(), time.Since(startTime))
 	}
 
+	ok, err := c.checkIf(job.If, scope, func() []string {
+		if len(files) > 0 {
+			return files
+		}
+
+		return c.hookFiles(scope)
+	})
+	if err != nil {
+		c.logger.LogSkipped(logName, "if: "+err.Error())
+
+		return result.Failure(name, "if: "+err.Error(), time.Since(startTime))
+	}
+	if !ok {
+		c.logger.LogSkipped(logName, "by condition")
+
+		return result.Skip(name, "by condition")
+	}
+
 	env := maps.Clone(scope.env)
 	maps.Copy(env, job.Env)
 
-	if job.Timeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
-		defer cancel()
+	var cacheKey string
+	if job.Cache && !scope.opts.NoCache {
+		cacheKey, err = c.cacheKey(scope, job, commands, files, env)
+		if err != nil {
+			c.logger.Debugf("[lefthook] cache: %s\n", err)
+		} else if c.cached(cacheKey) {
+			c.logger.LogSkipped(logName, "cached")
+
+			return result.Skip(name, "cached")
+		}
+	}
+
+	var outputFile string
+	if len(job.Outputs) > 0 {
+		outputFile, err = newOutputFile()
+		if err != nil {
+			c.logger.Warnf("Couldn't create the output file: %s\n", err)
+		} else {
+			defer func() { _ = os.Remove(outputFile) }()
+			env[envOutputFile] = outputFile
+		}
 	}
-	err = c.run(ctx, logName, scope.follow, exec.Options{
+
+	// Wait for the locks before occupying a slot to let other jobs run meanwhile
+	unlock := c.acquireLocks(logName, job.Locks)
+	defer unlock()
+
+	release := c.acquireSlot(scope, job.Interactive && !scope.opts.DisableTTY)
+	defer release()
+
+	if isCancelled(ctx) {
+		c.logger.LogSkipped(logName, "cancelled")
+
+		return result.Cancelled(name, 0)
+	}
+
+	// Don't count the time spent waiting for the locks and a free slot
+	startTime = time.Now()
+
+	opts := exec.Options{
 		Root:        filepath.Join(c.git.RootPath, scope.root),
 		Commands:    commands,
 		Interactive: job.Interactive && !scope.opts.DisableTTY,
 		UseStdin:    job.UseStdin,
 		Env:         env,
-	})
+	}
 
-	executionTime := time.Since(startTime)
+	executor := c.jobExecutor(scope, job)
+	endTrace := scope.opts.Tracer.Job(name, strings.Join(scope.names, " ❯ "))
+
+	var output string
+	var timedOut bool
+	attempts := max(job.Retries, 0) + 1
+	attempt := 1
+	for {
+		output, timedOut, err = c.runAttempt(ctx, executor, job.Timeout, logName, scope.follow, opts)
+		if err == nil || attempt == attempts || ctx.Err() != nil {
+			break
+		}
 
-	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return result.Failure(name, "timeout ("+job.Timeout.String()+")", executionTime)
+		c.logger.LogRetry(logName, attempt, attempts, job.RetryDelay)
+		if !sleep(ctx, job.RetryDelay) {
+			break
 		}
+		attempt++
+	}
 
-		return result.Failure(name, job.FailText, executionTime)
+	executionTime := time.Since(startTime)
+
+	var res result.Result
+	switch {
+	case err == nil:
+		res = result.Success(name, executionTime)
+	case isCancelled(ctx):
+		res = result.Cancelled(name, executionTime)
+	case job.AllowFailure:
+		res = result.Warning(name, failText(job, timedOut), executionTime)
+	default:
+		res = result.Failure(name, failText(job, timedOut), executionTime)
+	}
+	res.Attempts = attempt
+	res.Files = slices.Clone(files)
+	res.Commands = commands
+	res.ExitCode = exec.ExitCode(err)
+	res.Output = output
+	if err == nil && len(job.Outputs) > 0 {
+		res.Outputs = readOutputs(job.Outputs, output, outputFile)
+	}
+	endTrace(res.Status())
+
+	if err == nil && len(cacheKey) > 0 {
+		c.cache(cacheKey)
+	}
+
+	if err == nil && config.HookUsesStagedFiles(scope.hookName) && job.StageFixed && !scope.opts.NoStageFixed {
+		c.stageFixed(scope, files)
+	}
+
+	return res
+}
+
+// buildCommands returns the commands of the job and the files they are run for.
+// Built-in jobs have no commands.
+func (c *Controller) buildCommands(scope *scope, name string, job *config.Job) ([]string, []string, error) {
+	if job.LintCommitMsg != nil {
+		return nil, nil, nil
+	}
+
+	builder := command.NewBuilder(c.git, c.logger, command.BuilderOptions{
+		HookName:     scope.hookName,
+		ForceFiles:   scope.opts.Files,
+		DeletedFiles: scope.opts.DeletedFiles,
+		Force:        scope.opts.Force,
+		OnlyMatching: scope.opts.OnlyMatchingFiles,
+		SourceDirs:   scope.opts.SourceDirs,
+		GitArgs:      scope.opts.GitArgs,
+		Templates:    scope.opts.Templates,
+		GlobMatcher:  scope.opts.GlobMatcher,
+	})
+
+	return builder.BuildCommands(&command.JobParams{
+		Name:         name,
+		Run:          job.Run,
+		Runner:       job.Runner,
+		Args:         job.Args,
+		Script:       job.Script,
+		Only:         job.Only,
+		Skip:         job.Skip,
+		Root:         scope.root,
+		FileTypes:    scope.fileTypes,
+		Glob:         scope.glob,
+		FilesCmd:     scope.filesCmd,
+		Tags:         scope.tags,
+		ExcludeFiles: scope.excludeFiles,
+	})
+}
+
+// jobExecutor returns the executor of the job. Built-in jobs are executed
+// without a shell.
+func (c *Controller) jobExecutor(scope *scope, job *config.Job) exec.Executor {
+	if job.LintCommitMsg != nil {
+		return lintCommitMsgExecutor{
+			fs:      c.git.Fs,
+			root:    c.git.RootPath,
+			gitArgs: scope.opts.GitArgs,
+			rules:   job.LintCommitMsg,
+		}
 	}
 
-	if config.HookUsesStagedFiles(scope.hookName) && job.StageFixed && !scope.opts.NoStageFixed {
-		if len(files) == 0 {
-			var err error
-			files, err = c.git.StagedFiles()
-			if err != nil {
-				c.logger.Warn("Couldn't stage fixed files:", err)
-				return result.Success(name, executionTime)
-			}
+	return c.executor
+}
 
-			files = filter.New(c.git.Fs, c.logger, filter.Params{
-				Glob:         scope.glob,
-				Root:         scope.root,
-				ExcludeFiles: scope.excludeFiles,
-				FileTypes:    scope.fileTypes,
-				GlobMatcher:  scope.opts.GlobMatcher,
-			}).Apply(files)
+func (c *Controller) stageFixed(scope *scope, files []string) {
+	if len(files) == 0 {
+		var err error
+		files, err = c.git.StagedFiles()
+		if err != nil {
+			c.logger.Warn("Couldn't stage fixed files:", err)
+			return
 		}
 
-		if len(scope.root) > 0 {
-			for i, file := range files {
-				files[i] = filepath.Join(scope.root, file)
-			}
+		files = filter.New(c.git.Fs, c.logger, filter.Params{
+			Glob:         scope.glob,
+			Root:         scope.root,
+			ExcludeFiles: scope.excludeFiles,
+			FileTypes:    scope.fileTypes,
+			GlobMatcher:  scope.opts.GlobMatcher,
+		}).Apply(files)
+	}
+
+	if len(scope.root) > 0 {
+		for i, file := range files {
+			files[i] = filepath.Join(scope.root, file)
 		}
+	}
+
+	c.addStagedFiles(files)
+}
+
+func failText(job *config.Job, timedOut bool) string {
+	if timedOut {
+		return "timeout (" + job.Timeout.String() + ")"
+	}
 
-		c.addStagedFiles(files)
+	if len(job.FailText) == 0 && job.LintCommitMsg != nil {
+		return lintCommitMsgFailText
 	}
 
-	return result.Success(name, executionTime)
+	return job.FailText
+}
+
+// runAttempt executes the job commands once, respecting the job timeout.
+func (c *Controller) runAttempt(
+	ctx context.Context,
+	executor exec.Executor,
+	timeout time.Duration,
+	name string,
+	follow bool,
+	opts exec.Options,
+) (string, bool, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	output, err := c.run(ctx, executor, name, follow, opts)
+
+	return output, errors.Is(ctx.Err(), context.DeadlineExceeded), err
+}
+
+// sleep waits for the given duration. Returns false if the context was done earlier.
+func sleep(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
 
 func (c *Controller) addStagedFiles(files []string) {
@@ -10,9 +10,10 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/evilmartians/lefthook/v2/internal/git"
+	"github.com/evilmartians/lefthook/v2/internal/run/trace"
 )
 
-func (c *Controller) runLFSHook(ctx context.Context, hookName string, args []string) error {
+func (c *Controller) runLFSHook(ctx context.Context, hookName string, args []string, tracer *trace.Tracer) error {
 	if !git.IsLFSHook(hookName) {
 		return nil
 	}
@@ -54,6 +55,7 @@ func (c *Controller) runLFSHook(ctx context.Context, hookName string, args []str
 	)
 	out := new(bytes.Buffer)
 	errOut := new(bytes.Buffer)
+	endTrace := tracer.Span("git lfs "+hookName, "lfs")
 	err = c.cmd.RunWithContext(
 		ctx,
 		append(
@@ -65,6 +67,7 @@ func (c *Controller) runLFSHook(ctx context.Context, hookName string, args []str
 		out,
 		errOut,
 	)
+	endTrace()
 
 	outString := strings.Trim(out.String(), "\n")
 	if outString != "" {
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/evilmartians/lefthook/v2/internal/config"
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/commitmsg"
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/exec"
+)
+
+const lintCommitMsgFailText = "commit message doesn't follow the rules"
+
+var (
+	errLintCommitMsg      = errors.New(lintCommitMsgFailText)
+	errCommitMsgNotPassed = errors.New("lint_commit_msg: commit message file is not passed")
+)
+
+// lintCommitMsgExecutor checks the commit message file passed by Git as the
+// first argument instead of executing the commands.
+type lintCommitMsgExecutor struct {
+	fs      afero.Fs
+	root    string
+	gitArgs []string
+	rules   *config.LintCommitMsg
+}
+
+func (e lintCommitMsgExecutor) Execute(_ctx context.Context, _opts exec.Options, _in io.Reader, out io.Writer) error {
+	if len(e.gitArgs) == 0 {
+		return e.fail(out, errCommitMsgNotPassed)
+	}
+
+	path := e.gitArgs[0]
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(e.root, path)
+	}
+
+	message, err := afero.ReadFile(e.fs, path)
+	if err != nil {
+		return e.fail(out, fmt.Errorf("lint_commit_msg: %w", err))
+	}
+
+	violations, err := commitmsg.Lint(string(message), e.rules)
+	if err != nil {
+		return e.fail(out, fmt.Errorf("lint_commit_msg: %w", err))
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if _, err = io.WriteString(out, "✖ "+strings.Join(violations, "\n✖ ")+"\n"); err != nil {
+		return err
+	}
+
+	return errLintCommitMsg
+}
+
+// fail prints the error to the job output.
+func (e lintCommitMsgExecutor) fail(out io.Writer, err error) error {
+	if _, wErr := io.WriteString(out, err.Error()+"\n"); wErr != nil {
+		return errors.Join(err, wErr)
+	}
+
+	return err
+}
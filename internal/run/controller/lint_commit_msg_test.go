@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/tests/helpers/cmdtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/configtest"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/gittest"
+)
+
+func TestLintCommitMsg(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		message  string
+		args     []string
+		success  bool
+		output   string
+		failure  string
+		attempts int
+	}{
+		"valid message": {
+			message:  "feat(api): add users endpoint\n",
+			args:     []string{".git/COMMIT_EDITMSG"},
+			success:  true,
+			attempts: 1,
+		},
+		"invalid message": {
+			message: "feature(db): add users table\n",
+			args:    []string{".git/COMMIT_EDITMSG"},
+			output: "✖ type \"feature\" is not allowed, use one of: feat, fix\n" +
+				"✖ scope \"db\" is not allowed, use one of: api\n",
+			failure:  lintCommitMsgFailText,
+			attempts: 2,
+		},
+		"without message file": {
+			output:   "lint_commit_msg: commit message file is not passed\n",
+			failure:  lintCommitMsgFailText,
+			attempts: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(fs, filepath.Join(root, ".git", "COMMIT_EDITMSG"), []byte(tt.message), 0o644))
+
+			controller := newTestController(
+				gittest.NewRepositoryBuilder().Root(root).Cmd(cmdtest.NewTracking(nil)).Fs(fs).Build(),
+				nil,
+			)
+
+			hook := configtest.ParseHook(`
+    jobs:
+      - name: commit message
+        retries: 1
+        lint_commit_msg:
+          types: [feat, fix]
+          scopes: [api]
+  `)
+			hook.Name = "commit-msg"
+			results, err := controller.RunHook(t.Context(), Options{GitArgs: tt.args, SkipLFS: true}, hook)
+			assert.NoError(t, err)
+
+			assert.Len(t, results, 1)
+			assert.Equal(t, "commit message", results[0].Name)
+			assert.Equal(t, tt.success, results[0].Success())
+			assert.Equal(t, tt.output, results[0].Output)
+			assert.Equal(t, tt.failure, results[0].Text())
+			assert.Equal(t, tt.attempts, results[0].Attempts)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"slices"
+	"sync"
+)
+
+// locks serializes the jobs sharing the same lock names.
+type locks struct {
+	mu    sync.Mutex
+	named map[string]*sync.Mutex
+}
+
+func (l *locks) get(name string) *sync.Mutex {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.named == nil {
+		l.named = make(map[string]*sync.Mutex)
+	}
+
+	lock, ok := l.named[name]
+	if !ok {
+		lock = new(sync.Mutex)
+		l.named[name] = lock
+	}
+
+	return lock
+}
+
+// acquireLocks waits until the job gets all its `locks`. The job is shown
+// as locked in the spinner while waiting. Returns a function releasing the locks.
+func (c *Controller) acquireLocks(name string, names []string) func() {
+	if len(names) == 0 {
+		return func() {}
+	}
+
+	// Acquire the locks in the same order to avoid deadlocks
+	names = slices.Clone(names)
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	acquired := make([]*sync.Mutex, 0, len(names))
+	waiting := false
+	for _, lockName := range names {
+		lock := c.locks.get(lockName)
+		if !lock.TryLock() {
+			if !waiting {
+				waiting = true
+				c.logger.Spinner.AddLockedName(name)
+			}
+			lock.Lock()
+		}
+		acquired = append(acquired, lock)
+	}
+
+	if waiting {
+		c.logger.Spinner.RemoveLockedName(name)
+	}
+
+	return func() {
+		for _, lock := range acquired {
+			lock.Unlock()
+		}
+	}
+}
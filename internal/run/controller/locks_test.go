@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/exec"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/configtest"
+)
+
+// overlapExecutor records the commands which were executed simultaneously.
+type overlapExecutor struct {
+	mu       sync.Mutex
+	running  map[string]bool
+	overlaps map[[2]string]bool
+}
+
+func (e *overlapExecutor) Execute(_ctx context.Context, opts exec.Options, _in io.Reader, _out io.Writer) error {
+	command := opts.Commands[0]
+
+	e.mu.Lock()
+	for other := range e.running {
+		e.overlaps[[2]string{min(command, other), max(command, other)}] = true
+	}
+	e.running[command] = true
+	e.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	e.mu.Lock()
+	delete(e.running, command)
+	e.mu.Unlock()
+
+	return nil
+}
+
+func TestLocks(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	executor := &overlapExecutor{
+		running:  make(map[string]bool),
+		overlaps: make(map[[2]string]bool),
+	}
+	controller := newTestController(newTestRepo(root), executor)
+
+	hook := configtest.ParseHook(`
+    parallel: true
+    jobs:
+      - run: a
+        locks: [db]
+      - run: b
+        locks: [db, build]
+      - run: c
+        locks: [build]
+      - group:
+          parallel: true
+          jobs:
+            - run: d
+              locks: [db]
+  `)
+	hook.Name = "post-commit"
+	results, err := controller.RunHook(t.Context(), Options{MaxParallel: 4, SkipLFS: true}, hook)
+	assert.NoError(t, err)
+
+	for _, result := range results {
+		assert.True(t, result.Success())
+	}
+	for _, pair := range [][2]string{{"a", "b"}, {"a", "d"}, {"b", "d"}, {"b", "c"}} {
+		assert.False(t, executor.overlaps[pair], "%s and %s must not run simultaneously", pair[0], pair[1])
+	}
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"maps"
+	"os"
+	"strings"
+	"unicode"
+
+	"al.essio.dev/pkg/shellescape"
+)
+
+const envOutputFile = "LEFTHOOK_OUTPUT_FILE"
+
+// outputKey is the key of the stdout output of a job.
+const outputKey = "stdout"
+
+// newOutputFile creates a file where the job can write its outputs in `key=value` format.
+func newOutputFile() (string, error) {
+	file, err := os.CreateTemp("", "lefthook-output-*")
+	if err != nil {
+		return "", err
+	}
+
+	return file.Name(), file.Close()
+}
+
+// readOutputs returns the values of the declared outputs taken from the job
+// output or the output file. Missing outputs are empty.
+func readOutputs(keys []string, output string, outputFile string) map[string]string {
+	written := make(map[string]string)
+	if content, err := os.ReadFile(outputFile); err == nil {
+		for line := range strings.Lines(string(content)) {
+			key, value, ok := strings.Cut(strings.TrimRight(line, "\r\n"), "=")
+			if ok {
+				written[strings.TrimSpace(key)] = value
+			}
+		}
+	}
+
+	outputs := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if key == outputKey {
+			outputs[key] = strings.TrimSpace(strings.ReplaceAll(output, "\r\n", "\n"))
+		} else {
+			outputs[key] = written[key]
+		}
+	}
+
+	return outputs
+}
+
+// withOutputs returns a scope where the outputs of the previous jobs are available
+// as `{jobs.<name>.<key>}` templates and `LEFTHOOK_JOBS_<NAME>_<KEY>` ENV variables.
+// The templates are quoted, so the outputs can't inject shell code.
+func (s *scope) withOutputs(outputs map[string]map[string]string) *scope {
+	if len(outputs) == 0 {
+		return s
+	}
+
+	newScope := *s
+	newScope.opts.Templates = maps.Clone(s.opts.Templates)
+	if newScope.opts.Templates == nil {
+		newScope.opts.Templates = make(map[string]string)
+	}
+	newScope.env = maps.Clone(s.env)
+	if newScope.env == nil {
+		newScope.env = make(map[string]string)
+	}
+
+	for name, jobOutputs := range outputs {
+		for key, value := range jobOutputs {
+			newScope.opts.Templates["jobs."+name+"."+key] = shellescape.Quote(value)
+			newScope.env[outputEnvName(name, key)] = value
+		}
+	}
+
+	return &newScope
+}
+
+// outputEnvName returns the name of the ENV variable for the job output.
+func outputEnvName(name, key string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r)) {
+			return '_'
+		}
+
+		return unicode.ToUpper(r)
+	}, "LEFTHOOK_JOBS_"+name+"_"+key)
+}
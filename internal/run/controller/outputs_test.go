@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/exec"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/configtest"
+)
+
+// outputsExecutor prints the command, writes the outputs to the output file,
+// and records the commands with the ENV variables they were executed with.
+type outputsExecutor struct {
+	written  string
+	commands []string
+	envs     []map[string]string
+}
+
+func (e *outputsExecutor) Execute(_ctx context.Context, opts exec.Options, _in io.Reader, out io.Writer) error {
+	for _, command := range opts.Commands {
+		e.commands = append(e.commands, strings.TrimSpace(command))
+	}
+	e.envs = append(e.envs, opts.Env)
+
+	if outputFile, ok := opts.Env[envOutputFile]; ok {
+		if err := os.WriteFile(outputFile, []byte(e.written), 0o600); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(out, strings.Join(opts.Commands, " ")+"\r\n")
+	return err
+}
+
+func TestOutputs(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		hook     string
+		written  string
+		commands []string
+		env      map[string]string
+	}{
+		"piped": {
+			hook: `
+    piped: true
+    jobs:
+      - name: build
+        run: make build
+        outputs: [stdout, version, missing]
+      - name: publish
+        run: publish {jobs.build.version} {jobs.build.stdout} {jobs.build.missing}
+    `,
+			written:  "version=1.2\n",
+			commands: []string{"make build", "publish 1.2 'make build' ''"},
+			env: map[string]string{
+				"LEFTHOOK_JOBS_BUILD_STDOUT":  "make build",
+				"LEFTHOOK_JOBS_BUILD_VERSION": "1.2",
+				"LEFTHOOK_JOBS_BUILD_MISSING": "",
+			},
+		},
+		"not piped": {
+			hook: `
+    jobs:
+      - name: build
+        run: make build
+        outputs: [version]
+      - name: publish
+        run: publish {jobs.build.version}
+    `,
+			written:  "version=1.2\n",
+			commands: []string{"make build", "publish {jobs.build.version}"},
+			env:      map[string]string{},
+		},
+		"shell metacharacters": {
+			hook: `
+    piped: true
+    jobs:
+      - name: build
+        run: make build
+        outputs: [version]
+      - name: publish
+        run: publish {jobs.build.version}
+    `,
+			written:  "version=1.2; rm -rf ~ $(whoami) 'x'\n",
+			commands: []string{"make build", `publish '1.2; rm -rf ~ $(whoami) '"'"'x'"'"''`},
+			env: map[string]string{
+				"LEFTHOOK_JOBS_BUILD_VERSION": "1.2; rm -rf ~ $(whoami) 'x'",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			executor := &outputsExecutor{written: tt.written}
+			controller := newTestController(newTestRepo(root), executor)
+
+			hook := configtest.ParseHook(tt.hook)
+			hook.Name = "post-commit"
+			results, err := controller.RunHook(t.Context(), Options{SkipLFS: true}, hook)
+			assert.NoError(t, err)
+
+			for _, result := range results {
+				assert.True(t, result.Success())
+			}
+			assert.Equal(t, tt.commands, executor.commands)
+
+			assert.Len(t, executor.envs, 2)
+			for name, value := range tt.env {
+				assert.Equal(t, value, executor.envs[1][name])
+			}
+			assert.NotContains(t, executor.envs[1], envOutputFile)
+			if len(tt.env) == 0 {
+				assert.NotContains(t, executor.envs[1], "LEFTHOOK_JOBS_BUILD_VERSION")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/evilmartians/lefthook/v2/internal/git"
+)
+
+const (
+	templatePushRefs    = "push_refs"
+	templatePushCommits = "push_commits"
+
+	prePushArgsCount = 2
+)
+
+// preparePush parses the refs Git passes to pre-push hook via STDIN and adds
+// `{push_refs}` and `{push_commits}` templates. When the hook is run manually
+// the changes since the base ref are taken as pushed.
+func (c *Controller) preparePush(opts Options) Options {
+	// Git passes the remote name and URL to pre-push hook. Don't wait for the
+	// input when the hook is run manually
+	if len(opts.GitArgs) == prePushArgsCount && c.cachedStdin != nil &&
+		!isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		refs, err := git.ParsePushRefs(c.cachedStdin)
+		if err != nil {
+			c.logger.Debugf("[lefthook] couldn't read pushed refs: %s\n", err)
+		}
+
+		if len(refs) > 0 {
+			c.git.SetPushRefs(opts.GitArgs[0], refs)
+		}
+	} else {
+		c.git.SetPushRefsFromBase()
+	}
+
+	refs := make([]string, 0, len(c.git.PushRefs()))
+	for _, ref := range c.git.PushRefs() {
+		refs = append(refs, ref.RemoteRef)
+	}
+
+	commits, err := c.git.PushCommits()
+	if err != nil {
+		c.logger.Debugf("[lefthook] couldn't get pushed commits: %s\n", err)
+	}
+
+	templates := maps.Clone(opts.Templates)
+	if templates == nil {
+		templates = make(map[string]string)
+	}
+	templates[templatePushRefs] = strings.Join(refs, " ")
+	templates[templatePushCommits] = strings.Join(commits, " ")
+	opts.Templates = templates
+
+	return opts
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/evilmartians/lefthook/v2/internal/system"
 )
 
-func (c *Controller) run(ctx context.Context, name string, follow bool, opts exec.Options) error {
+// run executes the commands and returns the captured output. The output is not
+// captured in follow mode and for interactive jobs.
+func (c *Controller) run(ctx context.Context, executor exec.Executor, name string, follow bool, opts exec.Options) (string, error) {
 	c.logger.Spinner.AddName(name)
 	defer c.logger.Spinner.RemoveName(name)
 
@@ -31,12 +33,13 @@ func (c *Controller) run(ctx context.Context, name string, follow bool, opts exe
 			out = io.Discard
 		}
 
-		return c.executor.Execute(ctx, opts, in, out)
+		return "", executor.Execute(ctx, opts, in, out)
 	}
 
 	out := new(bytes.Buffer)
-	err := c.executor.Execute(ctx, opts, in, out)
+	err := executor.Execute(ctx, opts, in, out)
+	output := out.String()
 	c.logger.LogExecution(name, err, out)
 
-	return err
+	return output, err
 }
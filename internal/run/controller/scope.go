@@ -1,7 +1,10 @@
 package controller
 
 import (
+	"cmp"
+	"context"
 	"maps"
+	"runtime"
 	"slices"
 
 	"github.com/evilmartians/lefthook/v2/internal/config"
@@ -9,7 +12,8 @@ import (
 )
 
 type scope struct {
-	follow bool
+	follow   bool
+	failFast bool
 
 	glob         []string
 	tags         []string
@@ -18,6 +22,8 @@ type scope struct {
 	fileTypes    []string
 	excludeFiles []string
 	env          map[string]string
+	slots        []semaphore
+	cancel       context.CancelCauseFunc // Cancels the jobs sharing fail_fast
 	root         string
 	hookName     string
 	filesCmd     string
@@ -37,17 +43,38 @@ func newScope(hook *config.Hook, opts Options) *scope {
 		i += 1
 	}
 
+	var slots []semaphore
+	if maxParallel := cmp.Or(opts.MaxParallel, hook.MaxParallel, runtime.NumCPU()); maxParallel > 0 {
+		slots = append(slots, newSemaphore(maxParallel))
+	}
+
 	return &scope{
 		hookName:     hook.Name,
 		follow:       hook.Follow,
+		failFast:     opts.FailFast || hook.FailFast,
 		filesCmd:     hook.Files,
 		excludeTags:  hook.ExcludeTags,
 		excludeFiles: excludeFiles,
 		env:          make(map[string]string),
+		slots:        slots,
 		opts:         opts,
 	}
 }
 
+// withCancel returns a scope where failed jobs cancel the jobs started with
+// cancel along with the jobs of the enclosing scopes.
+func (s *scope) withCancel(cancel context.CancelCauseFunc) *scope {
+	newScope := *s
+	newScope.cancel = func(cause error) {
+		cancel(cause)
+		if s.cancel != nil {
+			s.cancel(cause)
+		}
+	}
+
+	return &newScope
+}
+
 func (s *scope) extend(job *config.Job) *scope {
 	newScope := *s
 	newScope.glob = slices.Concat(newScope.glob, job.Glob)
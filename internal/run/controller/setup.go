@@ -22,6 +22,8 @@ func (c *Controller) setup(
 	c.logger.Spinner.Stop()
 	defer c.logger.Spinner.Start()
 
+	defer opts.Tracer.Span("setup", "setup")()
+
 	replacer := replacer.New(c.git, c.logger, "", "").
 		AddTemplates(opts.Templates).
 		AddGitArgs(opts.GitArgs)
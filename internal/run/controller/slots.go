@@ -0,0 +1,52 @@
+package controller
+
+import "sync"
+
+// semaphore limits the number of simultaneously running jobs.
+type semaphore chan struct{}
+
+func newSemaphore(size int) semaphore {
+	return make(semaphore, size)
+}
+
+func (s semaphore) acquire() {
+	s <- struct{}{}
+}
+
+func (s semaphore) release() {
+	<-s
+}
+
+// terminal grants exclusive access to the terminal for interactive jobs.
+// Other jobs share the access and can run in parallel.
+type terminal struct {
+	mu sync.RWMutex
+}
+
+func (t *terminal) acquire(exclusive bool) func() {
+	if exclusive {
+		t.mu.Lock()
+		return t.mu.Unlock
+	}
+
+	t.mu.RLock()
+	return t.mu.RUnlock
+}
+
+// acquireSlot waits until the job can be executed considering `max_parallel`
+// settings of the hook and all parent groups. Returns a function releasing
+// the occupied slots.
+func (c *Controller) acquireSlot(scope *scope, interactive bool) func() {
+	// Acquire the innermost slot first to avoid holding outer slots while waiting
+	for i := len(scope.slots) - 1; i >= 0; i-- {
+		scope.slots[i].acquire()
+	}
+	releaseTerminal := c.terminal.acquire(interactive)
+
+	return func() {
+		releaseTerminal()
+		for _, slot := range scope.slots {
+			slot.release()
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/controller/exec"
+	"github.com/evilmartians/lefthook/v2/tests/helpers/configtest"
+)
+
+// countingExecutor tracks the maximum number of simultaneously executed jobs.
+type countingExecutor struct {
+	mu                 sync.Mutex
+	running            int
+	maxRunning         int
+	interactiveRunning bool
+
+	// interactiveMaxRunning is the maximum number of the jobs running
+	// together with the interactive job.
+	interactiveMaxRunning int
+}
+
+func (e *countingExecutor) Execute(_ctx context.Context, opts exec.Options, _in io.Reader, _out io.Writer) error {
+	e.mu.Lock()
+	e.running++
+	e.maxRunning = max(e.maxRunning, e.running)
+	if opts.Interactive {
+		e.interactiveRunning = true
+	}
+	if e.interactiveRunning {
+		e.interactiveMaxRunning = max(e.interactiveMaxRunning, e.running)
+	}
+	e.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	e.mu.Lock()
+	e.running--
+	if opts.Interactive {
+		e.interactiveRunning = false
+	}
+	e.mu.Unlock()
+
+	return nil
+}
+
+func TestMaxParallel(t *testing.T) {
+	root, err := filepath.Abs("src")
+	assert.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		hook                  string
+		maxParallel           int
+		maxRunning            int
+		interactiveMaxRunning int
+	}{
+		"hook setting": {
+			hook: `
+        parallel: true
+        max_parallel: 2
+        jobs:
+          - run: a
+          - run: b
+          - run: c
+          - run: d
+          - run: e
+      `,
+			maxRunning: 2,
+		},
+		"option overrides hook setting": {
+			hook: `
+        parallel: true
+        max_parallel: 2
+        jobs:
+          - run: a
+          - run: b
+          - run: c
+      `,
+			maxParallel: 1,
+			maxRunning:  1,
+		},
+		"group setting": {
+			hook: `
+        parallel: true
+        max_parallel: 4
+        jobs:
+          - group:
+              parallel: true
+              max_parallel: 1
+              jobs:
+                - run: a
+                - run: b
+                - run: c
+      `,
+			maxRunning: 1,
+		},
+		"interactive job": {
+			hook: `
+        parallel: true
+        max_parallel: 4
+        jobs:
+          - run: a
+          - run: b
+            interactive: true
+          - run: c
+          - run: d
+      `,
+			maxRunning:            4,
+			interactiveMaxRunning: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			executor := &countingExecutor{}
+			controller := newTestController(newTestRepo(root), executor)
+
+			hook := configtest.ParseHook(tt.hook)
+			hook.Name = "post-commit"
+			results, err := controller.RunHook(t.Context(), Options{
+				MaxParallel: tt.maxParallel,
+				SkipLFS:     true,
+			}, hook)
+			assert.NoError(t, err)
+
+			for _, result := range results {
+				assert.True(t, result.Success())
+			}
+			assert.LessOrEqual(t, executor.maxRunning, tt.maxRunning)
+			assert.Equal(t, tt.interactiveMaxRunning, executor.interactiveMaxRunning)
+		})
+	}
+}
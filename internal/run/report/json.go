@@ -0,0 +1,79 @@
+// Package report writes machine-readable reports of the hook execution.
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/result"
+)
+
+type jsonReport struct {
+	Hook     string    `json:"hook"`
+	Status   string    `json:"status"`
+	Duration float64   `json:"duration"`
+	Jobs     []jsonJob `json:"jobs"`
+}
+
+type jsonJob struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Duration   float64   `json:"duration"`
+	FailText   string    `json:"fail_text,omitempty"`
+	SkipReason string    `json:"skip_reason,omitempty"`
+	Attempts   int       `json:"attempts,omitempty"`
+	ExitCode   *int      `json:"exit_code,omitempty"`
+	Files      []string  `json:"files,omitempty"`
+	Commands   []string  `json:"commands,omitempty"`
+	Jobs       []jsonJob `json:"jobs,omitempty"`
+}
+
+// JSON writes the results of the hook as a JSON document.
+// Durations are in seconds.
+func JSON(w io.Writer, hookName string, duration time.Duration, results []result.Result) error {
+	report := jsonReport{
+		Hook:     hookName,
+		Status:   result.Group(hookName, results).Status(),
+		Duration: duration.Seconds(),
+		Jobs:     jsonJobs(results),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+func jsonJobs(results []result.Result) []jsonJob {
+	jobs := make([]jsonJob, 0, len(results))
+	for _, res := range results {
+		job := jsonJob{
+			Name:     res.Name,
+			Status:   res.Status(),
+			Duration: res.Duration.Seconds(),
+			Attempts: res.Attempts,
+			Files:    res.Files,
+			Commands: res.Commands,
+		}
+
+		if res.Skip() {
+			job.SkipReason = res.Text()
+		} else {
+			job.FailText = res.Text()
+		}
+
+		if len(res.Commands) > 0 {
+			exitCode := res.ExitCode
+			job.ExitCode = &exitCode
+		}
+
+		if len(res.Sub) > 0 {
+			job.Jobs = jsonJobs(res.Sub)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs
+}
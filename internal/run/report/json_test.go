@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/result"
+)
+
+func TestJSON(t *testing.T) {
+	lint := result.Failure("lint", "fix the code", 2*time.Second)
+	lint.Commands = []string{"eslint a.js"}
+	lint.Files = []string{"a.js"}
+	lint.ExitCode = 1
+	lint.Attempts = 1
+
+	results := []result.Result{
+		lint,
+		result.Skip("test", "by condition"),
+		result.Group("checks", []result.Result{
+			result.Success("format", time.Second),
+		}),
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, JSON(&buf, "pre-commit", 3*time.Second, results))
+	assert.JSONEq(t, `{
+		"hook": "pre-commit",
+		"status": "failure",
+		"duration": 3,
+		"jobs": [
+			{
+				"name": "lint",
+				"status": "failure",
+				"duration": 2,
+				"fail_text": "fix the code",
+				"attempts": 1,
+				"exit_code": 1,
+				"files": ["a.js"],
+				"commands": ["eslint a.js"]
+			},
+			{
+				"name": "test",
+				"status": "skip",
+				"duration": 0,
+				"skip_reason": "by condition"
+			},
+			{
+				"name": "checks",
+				"status": "success",
+				"duration": 1,
+				"jobs": [
+					{"name": "format", "status": "success", "duration": 1}
+				]
+			}
+		]
+	}`, buf.String())
+}
@@ -0,0 +1,132 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/result"
+)
+
+var ansiRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit writes the results of the hook as a JUnit XML document.
+//
+// The hook is reported as a testsuite and each job as a testcase. Jobs of
+// nested groups are flattened, the group names are added to the classname.
+func JUnit(w io.Writer, hookName string, startTime time.Time, duration time.Duration, results []result.Result) error {
+	suite := junitTestSuite{
+		Name:      hookName,
+		Time:      seconds(duration),
+		Timestamp: startTime.Format("2006-01-02T15:04:05"),
+	}
+	suite.TestCases = junitTestCases(hookName, results, nil)
+
+	for _, testCase := range suite.TestCases {
+		suite.Tests++
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+		if testCase.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+
+	suites := junitTestSuites{
+		Name:     "lefthook",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitTestCases(className string, results []result.Result, testCases []junitTestCase) []junitTestCase {
+	for _, res := range results {
+		if len(res.Sub) > 0 {
+			testCases = junitTestCases(className+"."+res.Name, res.Sub, testCases)
+			continue
+		}
+
+		output := ansiRegexp.ReplaceAllString(res.Output, "")
+		testCase := junitTestCase{
+			Name:      res.Name,
+			ClassName: className,
+			Time:      seconds(res.Duration),
+		}
+
+		switch {
+		case res.Failure():
+			message := res.Text()
+			if len(message) == 0 {
+				message = fmt.Sprintf("exit code %d", res.ExitCode)
+			}
+			testCase.Failure = &junitMessage{Message: message, Text: output}
+		case res.Skip():
+			testCase.Skipped = &junitMessage{Message: res.Text()}
+		case res.Cancelled():
+			testCase.Skipped = &junitMessage{Message: "cancelled"}
+		default:
+			testCase.SystemOut = output
+		}
+
+		testCases = append(testCases, testCase)
+	}
+
+	return testCases
+}
+
+func seconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/evilmartians/lefthook/v2/internal/run/result"
+)
+
+func TestJUnit(t *testing.T) {
+	lint := result.Failure("lint", "fix the code", 2*time.Second)
+	lint.Commands = []string{"eslint a.js"}
+	lint.ExitCode = 1
+	lint.Output = "\x1b[31ma.js: error\x1b[0m\n"
+
+	format := result.Success("format", time.Second)
+	format.Output = "formatted"
+
+	results := []result.Result{
+		lint,
+		result.Skip("test", "by condition"),
+		result.Group("checks", []result.Result{
+			format,
+			result.Cancelled("e2e", 0),
+		}),
+	}
+
+	var buf bytes.Buffer
+	startTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, JUnit(&buf, "pre-commit", startTime, 3*time.Second, results))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="lefthook" tests="4" failures="1" skipped="2" time="3.000">
+  <testsuite name="pre-commit" tests="4" failures="1" errors="0" skipped="2" time="3.000" timestamp="2024-01-02T03:04:05">
+    <testcase name="lint" classname="pre-commit" time="2.000">
+      <failure message="fix the code">a.js: error&#xA;</failure>
+    </testcase>
+    <testcase name="test" classname="pre-commit" time="0.000">
+      <skipped message="by condition"></skipped>
+    </testcase>
+    <testcase name="format" classname="pre-commit.checks" time="1.000">
+      <system-out>formatted</system-out>
+    </testcase>
+    <testcase name="e2e" classname="pre-commit.checks" time="0.000">
+      <skipped message="cancelled"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
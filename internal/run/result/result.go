@@ -8,15 +8,42 @@ const (
 	success status = iota
 	failure
 	skip
+	cancelled
+	warning
 )
 
+func (s status) String() string {
+	switch s {
+	case success:
+		return "success"
+	case failure:
+		return "failure"
+	case skip:
+		return "skip"
+	case cancelled:
+		return "cancelled"
+	case warning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
 // Result contains name of a command/script, an optional fail string, and execution duration.
+//
+// Files, Commands, ExitCode, Output, and Outputs are set for executed jobs only.
 type Result struct {
 	Sub      []Result
 	Name     string
 	text     string
 	status   status
 	Duration time.Duration
+	Attempts int
+	Files    []string
+	Commands []string
+	ExitCode int
+	Output   string
+	Outputs  map[string]string
 }
 
 func (r Result) Success() bool {
@@ -27,12 +54,30 @@ func (r Result) Failure() bool {
 	return r.status == failure
 }
 
+func (r Result) Cancelled() bool {
+	return r.status == cancelled
+}
+
+func (r Result) Warning() bool {
+	return r.status == warning
+}
+
+func (r Result) Skip() bool {
+	return r.status == skip
+}
+
+// Status returns a human readable status.
+func (r Result) Status() string {
+	return r.status.String()
+}
+
+// Text returns the fail text or the skip reason.
 func (r Result) Text() string {
 	return r.text
 }
 
-func Skip(name string) Result {
-	return Result{Name: name, status: skip}
+func Skip(name, reason string) Result {
+	return Result{Name: name, status: skip, text: reason}
 }
 
 func Success(name string, duration time.Duration) Result {
@@ -43,6 +88,16 @@ func Failure(name, text string, duration time.Duration) Result {
 	return Result{Name: name, status: failure, text: text, Duration: duration}
 }
 
+// Warning means the job failed but its failure is allowed.
+func Warning(name, text string, duration time.Duration) Result {
+	return Result{Name: name, status: warning, text: text, Duration: duration}
+}
+
+// Cancelled means the job was interrupted or not started because of a failure of another job.
+func Cancelled(name string, duration time.Duration) Result {
+	return Result{Name: name, status: cancelled, Duration: duration}
+}
+
 func Group(name string, results []Result) Result {
 	stat := success
 	allSkip := true
@@ -54,6 +109,16 @@ func Group(name string, results []Result) Result {
 		case failure:
 			stat = failure
 			allSkip = false
+		case cancelled:
+			if stat != failure {
+				stat = cancelled
+			}
+			allSkip = false
+		case warning:
+			if stat == success {
+				stat = warning
+			}
+			allSkip = false
 		case skip:
 		}
 		totalDuration += res.Duration
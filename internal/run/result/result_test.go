@@ -45,17 +45,17 @@ func TestGroup(t *testing.T) {
 		{
 			name: "all skip results",
 			results: []Result{
-				Skip("cmd1"),
-				Skip("cmd2"),
-				Skip("cmd3"),
+				Skip("cmd1", ""),
+				Skip("cmd2", ""),
+				Skip("cmd3", ""),
 			},
 			expected: Result{
 				Name:   "test-group",
 				status: skip,
 				Sub: []Result{
-					Skip("cmd1"),
-					Skip("cmd2"),
-					Skip("cmd3"),
+					Skip("cmd1", ""),
+					Skip("cmd2", ""),
+					Skip("cmd3", ""),
 				},
 				Duration: 0,
 			},
@@ -80,7 +80,7 @@ func TestGroup(t *testing.T) {
 			name: "mixed success and skip",
 			results: []Result{
 				Success("cmd1", 100*time.Millisecond),
-				Skip("cmd2"),
+				Skip("cmd2", ""),
 				Success("cmd3", 200*time.Millisecond),
 			},
 			expected: Result{
@@ -88,7 +88,7 @@ func TestGroup(t *testing.T) {
 				status: success,
 				Sub: []Result{
 					Success("cmd1", 100*time.Millisecond),
-					Skip("cmd2"),
+					Skip("cmd2", ""),
 					Success("cmd3", 200*time.Millisecond),
 				},
 				Duration: 300 * time.Millisecond,
@@ -115,17 +115,17 @@ func TestGroup(t *testing.T) {
 		{
 			name: "mixed skip and failure",
 			results: []Result{
-				Skip("cmd1"),
+				Skip("cmd1", ""),
 				Failure("cmd2", "failed", 100*time.Millisecond),
-				Skip("cmd3"),
+				Skip("cmd3", ""),
 			},
 			expected: Result{
 				Name:   "test-group",
 				status: failure,
 				Sub: []Result{
-					Skip("cmd1"),
+					Skip("cmd1", ""),
 					Failure("cmd2", "failed", 100*time.Millisecond),
-					Skip("cmd3"),
+					Skip("cmd3", ""),
 				},
 				Duration: 100 * time.Millisecond,
 			},
@@ -134,7 +134,7 @@ func TestGroup(t *testing.T) {
 			name: "all three statuses mixed",
 			results: []Result{
 				Success("cmd1", 50*time.Millisecond),
-				Skip("cmd2"),
+				Skip("cmd2", ""),
 				Failure("cmd3", "error", 25*time.Millisecond),
 				Success("cmd4", 125*time.Millisecond),
 			},
@@ -143,13 +143,95 @@ func TestGroup(t *testing.T) {
 				status: failure,
 				Sub: []Result{
 					Success("cmd1", 50*time.Millisecond),
-					Skip("cmd2"),
+					Skip("cmd2", ""),
 					Failure("cmd3", "error", 25*time.Millisecond),
 					Success("cmd4", 125*time.Millisecond),
 				},
 				Duration: 200 * time.Millisecond,
 			},
 		},
+		{
+			name: "all warning results",
+			results: []Result{
+				Warning("cmd1", "warn", 50*time.Millisecond),
+				Skip("cmd2", ""),
+			},
+			expected: Result{
+				Name:   "test-group",
+				status: warning,
+				Sub: []Result{
+					Warning("cmd1", "warn", 50*time.Millisecond),
+					Skip("cmd2", ""),
+				},
+				Duration: 50 * time.Millisecond,
+			},
+		},
+		{
+			name: "mixed success and warning",
+			results: []Result{
+				Success("cmd1", 50*time.Millisecond),
+				Warning("cmd2", "warn", 25*time.Millisecond),
+			},
+			expected: Result{
+				Name:   "test-group",
+				status: warning,
+				Sub: []Result{
+					Success("cmd1", 50*time.Millisecond),
+					Warning("cmd2", "warn", 25*time.Millisecond),
+				},
+				Duration: 75 * time.Millisecond,
+			},
+		},
+		{
+			name: "mixed warning and failure",
+			results: []Result{
+				Warning("cmd1", "warn", 50*time.Millisecond),
+				Failure("cmd2", "error", 25*time.Millisecond),
+			},
+			expected: Result{
+				Name:   "test-group",
+				status: failure,
+				Sub: []Result{
+					Warning("cmd1", "warn", 50*time.Millisecond),
+					Failure("cmd2", "error", 25*time.Millisecond),
+				},
+				Duration: 75 * time.Millisecond,
+			},
+		},
+		{
+			name: "mixed success and cancelled",
+			results: []Result{
+				Success("cmd1", 50*time.Millisecond),
+				Cancelled("cmd2", 25*time.Millisecond),
+			},
+			expected: Result{
+				Name:   "test-group",
+				status: cancelled,
+				Sub: []Result{
+					Success("cmd1", 50*time.Millisecond),
+					Cancelled("cmd2", 25*time.Millisecond),
+				},
+				Duration: 75 * time.Millisecond,
+			},
+		},
+		{
+			name: "mixed cancelled and failure",
+			results: []Result{
+				Cancelled("cmd1", 50*time.Millisecond),
+				Failure("cmd2", "error", 25*time.Millisecond),
+				Cancelled("cmd3", 0),
+			},
+			expected: Result{
+				Name:   "test-group",
+				status: failure,
+				Sub: []Result{
+					Cancelled("cmd1", 50*time.Millisecond),
+					Failure("cmd2", "error", 25*time.Millisecond),
+					Cancelled("cmd3", 0),
+				},
+				Duration: 75 * time.Millisecond,
+			},
+		},
 		{
 			name: "single success result",
 			results: []Result{
@@ -167,13 +249,13 @@ func TestGroup(t *testing.T) {
 		{
 			name: "single skip result",
 			results: []Result{
-				Skip("single-cmd"),
+				Skip("single-cmd", ""),
 			},
 			expected: Result{
 				Name:   "test-group",
 				status: skip,
 				Sub: []Result{
-					Skip("single-cmd"),
+					Skip("single-cmd", ""),
 				},
 				Duration: 0,
 			},
@@ -0,0 +1,197 @@
+// Package trace records the timeline of the hook execution in Chrome trace
+// event format. The result can be opened in chrome://tracing or Perfetto UI.
+package trace
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	pid     = 1
+	mainTid = 0
+)
+
+type event struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	Ts       float64        `json:"ts"`
+	Dur      *float64       `json:"dur,omitempty"`
+	Pid      int            `json:"pid"`
+	Tid      int            `json:"tid"`
+	ID       string         `json:"id,omitempty"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// Tracer collects trace events. A nil Tracer is valid and records nothing.
+type Tracer struct {
+	mu      sync.Mutex
+	start   time.Time
+	events  []event
+	lanes   []bool
+	groupID int
+}
+
+func New() *Tracer {
+	return &Tracer{start: time.Now()}
+}
+
+// Span records a phase executed by lefthook itself, e.g. setup or LFS hook.
+// Call the returned function when the phase is finished.
+func (t *Tracer) Span(name, category string) func() {
+	if t == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+
+	return func() {
+		t.complete(name, category, mainTid, start, nil)
+	}
+}
+
+// Job records the execution of a job. Each running job occupies a separate
+// lane (slot), so parallel jobs are shown on separate rows. Call the returned
+// function with the job status when the job is finished.
+func (t *Tracer) Job(name, group string) func(status string) {
+	if t == nil {
+		return func(string) {}
+	}
+
+	start := time.Now()
+	lane := t.acquireLane()
+
+	return func(status string) {
+		args := map[string]any{"status": status}
+		if len(group) > 0 {
+			args["group"] = group
+		}
+
+		t.releaseLane(lane)
+		t.complete(name, "job", lane+1, start, args)
+	}
+}
+
+// Group records the execution of a group of jobs. Groups are recorded as
+// async events because their jobs may run on different lanes.
+func (t *Tracer) Group(name string) func() {
+	if t == nil {
+		return func() {}
+	}
+
+	t.mu.Lock()
+	t.groupID++
+	id := strconv.Itoa(t.groupID)
+	t.events = append(t.events, event{
+		Name:     name,
+		Category: "group",
+		Phase:    "b",
+		Ts:       t.timestamp(time.Now()),
+		Pid:      pid,
+		Tid:      mainTid,
+		ID:       id,
+	})
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.events = append(t.events, event{
+			Name:     name,
+			Category: "group",
+			Phase:    "e",
+			Ts:       t.timestamp(time.Now()),
+			Pid:      pid,
+			Tid:      mainTid,
+			ID:       id,
+		})
+	}
+}
+
+// Write writes the recorded events as a JSON object.
+func (t *Tracer) Write(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	events := make([]event, 0, len(t.events)+len(t.lanes)+2)
+	events = append(events,
+		metadata("process_name", mainTid, "lefthook"),
+		metadata("thread_name", mainTid, "lefthook"),
+	)
+	for lane := range t.lanes {
+		events = append(events, metadata("thread_name", lane+1, "slot "+strconv.Itoa(lane+1)))
+	}
+	events = append(events, t.events...)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(struct {
+		TraceEvents     []event `json:"traceEvents"`
+		DisplayTimeUnit string  `json:"displayTimeUnit"`
+	}{
+		TraceEvents:     events,
+		DisplayTimeUnit: "ms",
+	})
+}
+
+func (t *Tracer) complete(name, category string, tid int, start time.Time, args map[string]any) {
+	dur := float64(time.Since(start).Microseconds())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event{
+		Name:     name,
+		Category: category,
+		Phase:    "X",
+		Ts:       t.timestamp(start),
+		Dur:      &dur,
+		Pid:      pid,
+		Tid:      tid,
+		Args:     args,
+	})
+}
+
+// acquireLane returns the index of the first free lane.
+func (t *Tracer) acquireLane() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, busy := range t.lanes {
+		if !busy {
+			t.lanes[i] = true
+			return i
+		}
+	}
+
+	t.lanes = append(t.lanes, true)
+
+	return len(t.lanes) - 1
+}
+
+func (t *Tracer) releaseLane(lane int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lanes[lane] = false
+}
+
+func (t *Tracer) timestamp(at time.Time) float64 {
+	return float64(at.Sub(t.start).Microseconds())
+}
+
+func metadata(name string, tid int, value string) event {
+	return event{
+		Name:  name,
+		Phase: "M",
+		Pid:   pid,
+		Tid:   tid,
+		Args:  map[string]any{"name": value},
+	}
+}
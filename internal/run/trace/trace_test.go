@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracer(t *testing.T) {
+	tracer := New()
+
+	endHook := tracer.Span("pre-commit", "hook")
+	endGroup := tracer.Group("checks")
+	endLint := tracer.Job("lint", "checks")
+	endTest := tracer.Job("test", "checks")
+	endLint("success")
+	endFormat := tracer.Job("format", "")
+	endTest("failure")
+	endFormat("skip")
+	endGroup()
+	endHook()
+
+	var buf bytes.Buffer
+	assert.NoError(t, tracer.Write(&buf))
+
+	var out struct {
+		TraceEvents []event `json:"traceEvents"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+
+	tids := make(map[string]int)
+	phases := make(map[string][]string)
+	var threads []string
+	for _, e := range out.TraceEvents {
+		if e.Phase == "M" {
+			if e.Name == "thread_name" {
+				threads = append(threads, e.Args["name"].(string))
+			}
+			continue
+		}
+
+		tids[e.Name] = e.Tid
+		phases[e.Name] = append(phases[e.Name], e.Phase)
+	}
+
+	assert.Equal(t, []string{"lefthook", "slot 1", "slot 2"}, threads)
+	assert.Equal(t, map[string]int{
+		"pre-commit": 0,
+		"checks":     0,
+		"lint":       1,
+		"test":       2,
+		"format":     1,
+	}, tids)
+	assert.Equal(t, []string{"b", "e"}, phases["checks"])
+	assert.Equal(t, []string{"X"}, phases["lint"])
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+
+	tracer.Span("setup", "setup")()
+	tracer.Group("group")()
+	tracer.Job("job", "")("success")
+}
@@ -13,6 +13,9 @@ import (
 
 const checksumFormat = "%s %d %s\n"
 
+// GlobalHookFingerprint identifies the hooks installed with `lefthook install --global`.
+const GlobalHookFingerprint = "LEFTHOOK_GLOBAL_HOOK"
+
 //go:embed *
 var templatesFS embed.FS
 
@@ -20,7 +23,13 @@ type Args struct {
 	Rc                      string
 	LefthookPath            string
 	AssertLefthookInstalled bool
+	Chain                   bool
 	Roots                   []string
+
+	// Global hook runs lefthook only if one of ConfigFiles exists in the
+	// repository, otherwise it runs the repository's own hook.
+	Global      bool
+	ConfigFiles []string
 }
 
 type hookTmplData struct {
@@ -31,6 +40,10 @@ type hookTmplData struct {
 	Rc                      string
 	Roots                   []string
 	AssertLefthookInstalled bool
+	Chain                   bool
+	Global                  bool
+	ConfigFiles             []string
+	GlobalFingerprint       string
 }
 
 func Hook(hookName string, args Args) []byte {
@@ -46,6 +59,10 @@ func Hook(hookName string, args Args) []byte {
 		Extension:               getExtension(),
 		Rc:                      args.Rc,
 		AssertLefthookInstalled: args.AssertLefthookInstalled,
+		Chain:                   args.Chain,
+		Global:                  args.Global,
+		ConfigFiles:             args.ConfigFiles,
+		GlobalFingerprint:       GlobalHookFingerprint,
 		Roots:                   args.Roots,
 		LefthookPath:            filepath.ToSlash(strings.ReplaceAll(strings.TrimSpace(args.LefthookPath), "\n", ";")),
 		LefthookPathCurrent:     filepath.ToSlash(lefthookPathCurrent),
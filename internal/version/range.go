@@ -0,0 +1,207 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+const maxParts = 3
+
+var (
+	rangeOperatorRegexp = regexp.MustCompile(`^(\^|~|>=|<=|>|<|=)?\s*(.+)$`)
+	wildcardRegexp      = regexp.MustCompile(`^v?\d+(\.(\d+|[xX*]))*$`)
+)
+
+// comparator is a single condition of a range, e.g. >= v1.2.0.
+type comparator struct {
+	op      string
+	version string
+}
+
+// IsRange tells whether the ref is a version range like ^1.2, ~1.2.3,
+// >=1.2 <2, or 1.x rather than a branch or a tag name.
+func IsRange(ref string) bool {
+	if len(ref) == 0 {
+		return false
+	}
+
+	if strings.ContainsAny(ref[:1], "^~<>=*") {
+		return true
+	}
+
+	return wildcardRegexp.MatchString(ref) && strings.ContainsAny(ref, "xX*")
+}
+
+// MaxSatisfying returns the highest version from the list matching the range.
+// Pre-release versions are skipped.
+func MaxSatisfying(constraint string, versions []string) (string, error) {
+	comparators, err := parseRange(constraint)
+	if err != nil {
+		return "", err
+	}
+
+	var best, bestSemver string
+	for _, v := range versions {
+		sv := canonical(v)
+		if !semver.IsValid(sv) || len(semver.Prerelease(sv)) > 0 {
+			continue
+		}
+
+		if !satisfies(sv, comparators) {
+			continue
+		}
+
+		if len(best) == 0 || semver.Compare(sv, bestSemver) > 0 {
+			best, bestSemver = v, sv
+		}
+	}
+
+	if len(best) == 0 {
+		return "", fmt.Errorf("no versions match %s", constraint)
+	}
+
+	return best, nil
+}
+
+func satisfies(v string, comparators []comparator) bool {
+	for _, c := range comparators {
+		cmp := semver.Compare(v, c.version)
+		var ok bool
+		switch c.op {
+		case ">=":
+			ok = cmp >= 0
+		case ">":
+			ok = cmp > 0
+		case "<=":
+			ok = cmp <= 0
+		case "<":
+			ok = cmp < 0
+		default:
+			ok = cmp == 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseRange converts the range into a list of comparators which all must
+// be satisfied.
+func parseRange(constraint string) ([]comparator, error) {
+	var comparators []comparator
+	for _, field := range strings.FieldsFunc(constraint, func(r rune) bool { return r == ' ' || r == ',' }) {
+		match := rangeOperatorRegexp.FindStringSubmatch(field)
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVersion, constraint)
+		}
+
+		op, partial := match[1], match[2]
+		if partial == "*" || partial == "x" || partial == "X" {
+			continue
+		}
+
+		parts, err := parsePartial(partial)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVersion, constraint)
+		}
+
+		comparators = append(comparators, expand(op, parts)...)
+	}
+
+	return comparators, nil
+}
+
+// parsePartial parses a version with possibly omitted or wildcard parts,
+// e.g. 1.2 or 1.2.x.
+func parsePartial(partial string) ([]int, error) {
+	partial = strings.TrimPrefix(partial, "v")
+
+	var parts []int
+	for part := range strings.SplitSeq(partial, ".") {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, ErrInvalidVersion
+		}
+		parts = append(parts, n)
+	}
+
+	if len(parts) == 0 || len(parts) > maxParts {
+		return nil, ErrInvalidVersion
+	}
+
+	return parts, nil
+}
+
+func expand(op string, parts []int) []comparator {
+	lower := format(parts)
+	full := len(parts) == maxParts
+
+	switch op {
+	case "^":
+		// Allow changes not modifying the first non-zero part.
+		bump := 0
+		for bump < len(parts)-1 && parts[bump] == 0 {
+			bump++
+		}
+		return []comparator{{">=", lower}, {"<", format(increment(parts, bump))}}
+	case "~":
+		bump := min(1, len(parts)-1)
+		return []comparator{{">=", lower}, {"<", format(increment(parts, bump))}}
+	case ">=", "<":
+		return []comparator{{op, lower}}
+	case ">":
+		if full {
+			return []comparator{{">", lower}}
+		}
+		return []comparator{{">=", format(increment(parts, len(parts)-1))}}
+	case "<=":
+		if full {
+			return []comparator{{"<=", lower}}
+		}
+		return []comparator{{"<", format(increment(parts, len(parts)-1))}}
+	default:
+		if full {
+			return []comparator{{"=", lower}}
+		}
+		return []comparator{{">=", lower}, {"<", format(increment(parts, len(parts)-1))}}
+	}
+}
+
+// increment bumps the part at the index and drops the following parts.
+func increment(parts []int, index int) []int {
+	result := append([]int(nil), parts[:index+1]...)
+	result[index]++
+
+	return result
+}
+
+func format(parts []int) string {
+	full := make([]string, maxParts)
+	for i := range full {
+		full[i] = "0"
+		if i < len(parts) {
+			full[i] = strconv.Itoa(parts[i])
+		}
+	}
+
+	return "v" + strings.Join(full, ".")
+}
+
+func canonical(v string) string {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+
+	return v
+}
@@ -0,0 +1,58 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRange(t *testing.T) {
+	for ref, ok := range map[string]bool{
+		"^1.2":     true,
+		"~1.2.3":   true,
+		">=1.2 <2": true,
+		"1.x":      true,
+		"v1.2.*":   true,
+		"*":        true,
+		"v1.2.3":   false,
+		"main":     false,
+		"fix.x":    false,
+		"":         false,
+	} {
+		t.Run(ref, func(t *testing.T) {
+			assert.Equal(t, ok, IsRange(ref))
+		})
+	}
+}
+
+func TestMaxSatisfying(t *testing.T) {
+	tags := []string{"v0.9.0", "v1.1.0", "v1.2.0", "v1.2.5", "v1.3.0-rc.1", "v1.10.2", "v2.0.0", "latest", "1.4.0"}
+
+	for constraint, want := range map[string]string{
+		"^1.2":        "v1.10.2",
+		"^1.2.5":      "v1.10.2",
+		"~1.2":        "v1.2.5",
+		"~1.2.0":      "v1.2.5",
+		"1.2.x":       "v1.2.5",
+		"1.x":         "v1.10.2",
+		">=1.2 <1.4":  "v1.2.5",
+		">=1.2, <1.5": "1.4.0",
+		">1.2":        "v2.0.0",
+		"<=1.2":       "v1.2.5",
+		"^0.9":        "v0.9.0",
+		"*":           "v2.0.0",
+		"=1.1.0":      "v1.1.0",
+		"^3":          "",
+	} {
+		t.Run(constraint, func(t *testing.T) {
+			tag, err := MaxSatisfying(constraint, tags)
+			if len(want) == 0 {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, want, tag)
+		})
+	}
+}